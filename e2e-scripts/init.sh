@@ -15,3 +15,37 @@ hello() {
 }
 ' > ~/.config/wo/workspaces/api/functions/functions.sh
 }
+
+# Exercise the POSIX setup in its own directories to not interfere with
+# the shared tests
+(
+  export XDG_CONFIG_HOME=/tmp/posix/config
+  export XDG_STATE_HOME=/tmp/posix/state
+  export XDG_CACHE_HOME=/tmp/posix/cache
+  mkdir -p /tmp/posix/workspace
+  wo create posix /tmp/posix/workspace || exit 1
+  wo create posix-api /tmp/posix/workspace || exit 1
+  printf '# Say hello\nhello() {\n  echo "Hello"\n}\n' > /tmp/posix/config/wo/workspaces/posix/functions/functions.sh
+
+  wo setup sh > /tmp/posix/setup || exit 1
+  . /tmp/posix/setup
+
+  # Aliases are defined as functions when possible
+  grep -q '^c_posix() { cd /tmp/posix/workspace; }$' /tmp/posix/setup || exit 1
+  grep -q '^alias c_posix-api="cd /tmp/posix/workspace"$' /tmp/posix/setup || exit 1
+  cd /
+  c_posix
+  test "$(pwd)" = "/tmp/posix/workspace" || exit 1
+
+  # Completion
+  printf 'posix\nposix-api\n' > /tmp/posix/expected-workspaces
+  wo_complete run po > /tmp/posix/actual-workspaces || exit 1
+  diff /tmp/posix/expected-workspaces /tmp/posix/actual-workspaces || exit 1
+
+  printf 'hello\tSay hello\n' > /tmp/posix/expected-functions
+  wo_complete run posix "" > /tmp/posix/actual-functions || exit 1
+  diff /tmp/posix/expected-functions /tmp/posix/actual-functions || exit 1
+
+  wo_complete | grep -q '^setup' || exit 1
+) || exit 1
+rm -rf /tmp/posix
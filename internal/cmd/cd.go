@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newCdCmd(workspaceManager workspaceManager, completionManager completionManager) *cobra.Command {
+	return &cobra.Command{
+		Use:               "cd workspace[/folder]",
+		Short:             "Print the path of a workspace, the shell function defined by the setup command jumps into it",
+		Long:              "Print the path of a workspace or of a folder inside it, the workspace name can be abbreviated with a prefix or its characters in order, e.g. fed for frontend. The shell function defined by the setup command jumps into the path when the output is a terminal and prints it otherwise.",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completionManager.Process,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := workspaceManager.ResolvePath(args[0])
+			if err != nil {
+				return err
+			}
+			cmd.Println(path)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newConfigGetCmd(workspaceManager workspaceManager, completionManager completionManager) *cobra.Command {
+	return &cobra.Command{
+		Use:               "get workspace key",
+		Short:             "Get a configuration",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completionManager.Process,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := workspaceManager.GetConfig(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			cmd.Printf("%s", value)
+			return nil
+		},
+	}
+}
@@ -1,6 +1,10 @@
 package cmd
 
 import (
+	"errors"
+	"strings"
+
+	"github.com/antham/wo/internal/cmd/internal/validator"
 	"github.com/spf13/cobra"
 )
 
@@ -10,8 +14,21 @@ func newConfigSetCmd(workspaceManager workspaceManager, completionManager comple
 		Short:             "Set a configuration",
 		Args:              cobra.ExactArgs(3),
 		ValidArgsFunction: completionManager.Process,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if args[1] != "tags" {
+				return nil
+			}
+			// The tags are checked as when they are added with the tag command
+			errs := []error{}
+			for _, tag := range strings.Split(args[2], ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					errs = append(errs, validator.ValidateName(tag))
+				}
+			}
+			return errors.Join(errs...)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			err := workspaceManager.SetConfig(args[0], map[string]string{args[1]: args[2]})
+			err := workspaceManager.SetConfig(args[0], map[string]any{args[1]: args[2]})
 			if err != nil {
 				return err
 			}
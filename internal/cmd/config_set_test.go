@@ -21,7 +21,7 @@ func TestNewConfigSetCmd(t *testing.T) {
 			func(t *testing.T) (workspaceManager, []string) {
 				w := newMockWorkspaceManager(t)
 				args := []string{"api", "path", "/home/user/project"}
-				w.Mock.On("SetConfig", args[0], map[string]string{args[1]: args[2]}).Return(errors.New("an error occurred"))
+				w.Mock.On("SetConfig", args[0], map[string]any{args[1]: args[2]}).Return(errors.New("an error occurred"))
 				return w, args
 			},
 			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
@@ -33,7 +33,7 @@ func TestNewConfigSetCmd(t *testing.T) {
 			func(t *testing.T) (workspaceManager, []string) {
 				w := newMockWorkspaceManager(t)
 				args := []string{"api", "path", "/home/user/project"}
-				w.Mock.On("SetConfig", args[0], map[string]string{args[1]: args[2]}).Return(nil)
+				w.Mock.On("SetConfig", args[0], map[string]any{args[1]: args[2]}).Return(nil)
 				return w, args
 			},
 			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
@@ -41,6 +41,27 @@ func TestNewConfigSetCmd(t *testing.T) {
 				assert.Equal(t, "Config key 'path' edited on workspace 'api'\n", outBuf.String())
 			},
 		},
+		{
+			"Setting valid tags",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				args := []string{"api", "tags", "backend, go"}
+				w.Mock.On("SetConfig", args[0], map[string]any{args[1]: args[2]}).Return(nil)
+				return w, args
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"Setting invalid tags",
+			func(t *testing.T) (workspaceManager, []string) {
+				return newMockWorkspaceManager(t), []string{"api", "tags", "backend,my tag,a/b"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "`my tag` must comprise letters, numbers, underscore, dash and not have more than 50 characters\n`a/b` must comprise letters, numbers, underscore, dash and not have more than 50 characters")
+			},
+		},
 	}
 	for _, s := range scenarios {
 		t.Run(s.name, func(t *testing.T) {
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newConfigUnsetCmd(workspaceManager workspaceManager, completionManager completionManager) *cobra.Command {
+	return &cobra.Command{
+		Use:               "unset workspace key",
+		Short:             "Unset a configuration",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completionManager.Process,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := workspaceManager.UnsetConfig(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			cmd.Printf(
+				regularStyle.Render("Config key '")+highlightedStyle.Render("%s")+regularStyle.Render("' removed from workspace '")+highlightedStyle.Render("%s")+"'\n",
+				args[1], args[0],
+			)
+			return nil
+		},
+	}
+}
@@ -2,27 +2,36 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/antham/wo/internal/cmd/internal/validator"
 	"github.com/spf13/cobra"
 )
 
 func newCreateCmd(workspaceManager workspaceManager, completionManager completionManager) *cobra.Command {
-	return &cobra.Command{
+	var app string
+	cmd := &cobra.Command{
 		Use:               "create workspace project-path",
 		Short:             "Create a workspace",
 		Args:              cobra.ExactArgs(2),
 		ValidArgsFunction: completionManager.Process,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if app != "" && !slices.Contains(workspaceManager.GetSupportedApps(), app) {
+				return fmt.Errorf(`"%s" app is not supported, must be one of: %s`, app, strings.Join(workspaceManager.GetSupportedApps(), ", "))
+			}
 			return errors.Join(validator.ValidateName(args[0]))
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			err := workspaceManager.Create(args[0], args[1])
+			err := workspaceManager.Create(args[0], args[1], app)
 			if err != nil {
 				return err
 			}
-			cmd.Printf(regularStyle.Render("Workspace '")+highlightedStyle.Render("%s")+regularStyle.Render("' created on path '")+highlightedStyle.Render("%s")+regularStyle.Render("', reload your shell to setup the project aliases")+"\n", args[0], args[1])
+			cmd.Printf(regularStyle.Render("Workspace '")+highlightedStyle.Render("%s")+regularStyle.Render("' created on path '")+highlightedStyle.Render("%s")+regularStyle.Render("'")+"\n", args[0], args[1])
 			return nil
 		},
 	}
+	cmd.Flags().StringVarP(&app, "app", "a", "", "Shell used to run the functions of the workspace, defaults to the current shell")
+	return cmd
 }
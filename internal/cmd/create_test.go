@@ -21,7 +21,7 @@ func TestNewCreateCmd(t *testing.T) {
 			func(t *testing.T) (workspaceManager, []string) {
 				w := newMockWorkspaceManager(t)
 				args := []string{"api", "/tmp/project"}
-				w.Mock.On("Create", args[0], args[1]).Return(errors.New("an error occurred"))
+				w.Mock.On("Create", args[0], args[1], "").Return(errors.New("an error occurred"))
 				return w, args
 			},
 			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
@@ -42,12 +42,37 @@ func TestNewCreateCmd(t *testing.T) {
 			func(t *testing.T) (workspaceManager, []string) {
 				w := newMockWorkspaceManager(t)
 				args := []string{"api", "/tmp/project"}
-				w.Mock.On("Create", args[0], args[1]).Return(nil)
+				w.Mock.On("Create", args[0], args[1], "").Return(nil)
 				return w, args
 			},
 			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
 				assert.NoError(t, err)
-				assert.Equal(t, "Workspace 'api' created on path '/tmp/project', reload your shell to setup the project aliases\n", outBuf.String())
+				assert.Equal(t, "Workspace 'api' created on path '/tmp/project'\n", outBuf.String())
+			},
+		},
+		{
+			"Creating a workspace bound to another shell",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				args := []string{"api", "/tmp/project", "--app", "bash"}
+				w.Mock.On("GetSupportedApps").Return([]string{"fish", "bash", "zsh", "sh"})
+				w.Mock.On("Create", args[0], args[1], "bash").Return(nil)
+				return w, args
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"Creating a workspace bound to an unsupported shell",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				args := []string{"api", "/tmp/project", "--app", "tcsh"}
+				w.Mock.On("GetSupportedApps").Return([]string{"fish", "bash", "zsh", "sh"})
+				return w, args
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, `"tcsh" app is not supported, must be one of: fish, bash, zsh, sh`)
 			},
 		},
 	}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 )
 
@@ -8,9 +10,17 @@ func newFixCmd(workspaceManager workspaceManager) *cobra.Command {
 	return &cobra.Command{
 		Use:   "fix",
 		Short: "Fix the possible failures in the config folder",
+		Long:  "Fix the possible failures in the config folder, the config folder of the previous versions is moved to the XDG config folder first",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			err := workspaceManager.Fix()
+			from, err := workspaceManager.MigrateConfigDir()
+			if err != nil {
+				return err
+			}
+			if from != "" {
+				cmd.Println(regularStyle.Render(fmt.Sprintf(`Config folder "%s" moved to "%s"`, from, workspaceManager.GetConfigDir())))
+			}
+			err = workspaceManager.Fix()
 			if err != nil {
 				return err
 			}
@@ -16,10 +16,22 @@ func TestNewFixCmd(t *testing.T) {
 		test  func(*testing.T, *bytes.Buffer, *bytes.Buffer, error)
 	}
 	scenarios := []scenario{
+		{
+			"An error occurred when moving the legacy config folder",
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("MigrateConfigDir").Return("/home/user/.config/wo", errors.New("an error occurred"))
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.Error(t, err)
+			},
+		},
 		{
 			"An error occurred when fixing the config",
 			func(t *testing.T) workspaceManager {
 				w := newMockWorkspaceManager(t)
+				w.Mock.On("MigrateConfigDir").Return("", nil)
 				w.Mock.On("Fix").Return(errors.New("an error occurred"))
 				return w
 			},
@@ -31,6 +43,7 @@ func TestNewFixCmd(t *testing.T) {
 			"Creating a workspace env successfully",
 			func(t *testing.T) workspaceManager {
 				w := newMockWorkspaceManager(t)
+				w.Mock.On("MigrateConfigDir").Return("", nil)
 				w.Mock.On("Fix").Return(nil)
 				return w
 			},
@@ -39,6 +52,20 @@ func TestNewFixCmd(t *testing.T) {
 				assert.Equal(t, "Config folder fixed", outBuf.String())
 			},
 		},
+		{
+			"Moving the legacy config folder",
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("MigrateConfigDir").Return("/home/user/.config/wo", nil)
+				w.Mock.On("GetConfigDir").Return("/tmp/config/wo")
+				w.Mock.On("Fix").Return(nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "Config folder \"/home/user/.config/wo\" moved to \"/tmp/config/wo\"\nConfig folder fixed", outBuf.String())
+			},
+		},
 	}
 	for _, s := range scenarios {
 		t.Run(s.name, func(t *testing.T) {
@@ -1,8 +1,6 @@
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 )
 
@@ -16,9 +14,19 @@ func newGlobalGetCmd(workspaceManager workspaceManager, completionManager comple
 			switch args[0] {
 			case "config-dir":
 				cmd.Printf("%s", workspaceManager.GetConfigDir())
-			default:
-				return fmt.Errorf("Key '%s' does not exist", args[0])
+				return nil
+			case "state-dir":
+				cmd.Printf("%s", workspaceManager.GetStateDir())
+				return nil
+			case "cache-dir":
+				cmd.Printf("%s", workspaceManager.GetCacheDir())
+				return nil
+			}
+			value, err := workspaceManager.GetGlobalConfig(args[0])
+			if err != nil {
+				return err
 			}
+			cmd.Printf("%s", value)
 			return nil
 		},
 	}
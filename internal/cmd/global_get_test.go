@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"testing"
 
@@ -18,7 +19,10 @@ func TestNewGlobalGetCmd(t *testing.T) {
 		{
 			"An error occurred when getting an unexisting config",
 			func(t *testing.T) (workspaceManager, []string) {
-				return nil, []string{"whatever"}
+				w := newMockWorkspaceManager(t)
+				args := []string{"whatever"}
+				w.Mock.On("GetGlobalConfig", args[0]).Return("", errors.New(`"whatever" is not a valid global config key`))
+				return w, args
 			},
 			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
 				assert.Error(t, err)
@@ -37,6 +41,45 @@ func TestNewGlobalGetCmd(t *testing.T) {
 				assert.Equal(t, "/home/user/config", outBuf.String())
 			},
 		},
+		{
+			"Getting the state directory",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				args := []string{"state-dir"}
+				w.Mock.On("GetStateDir").Return("/home/user/.local/state/wo")
+				return w, args
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "/home/user/.local/state/wo", outBuf.String())
+			},
+		},
+		{
+			"Getting the cache directory",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				args := []string{"cache-dir"}
+				w.Mock.On("GetCacheDir").Return("/home/user/.cache/wo")
+				return w, args
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "/home/user/.cache/wo", outBuf.String())
+			},
+		},
+		{
+			"Getting a global config",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				args := []string{"theme"}
+				w.Mock.On("GetGlobalConfig", args[0]).Return("dark", nil)
+				return w, args
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "dark", outBuf.String())
+			},
+		},
 	}
 	for _, s := range scenarios {
 		t.Run(s.name, func(t *testing.T) {
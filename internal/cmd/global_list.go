@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newGlobalListCmd(workspaceManager workspaceManager) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the global configuration",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := workspaceManager.ListGlobalConfig()
+			if err != nil {
+				return err
+			}
+			config["config-dir"] = workspaceManager.GetConfigDir()
+			config["state-dir"] = workspaceManager.GetStateDir()
+			config["cache-dir"] = workspaceManager.GetCacheDir()
+			var configs []string
+			for _, key := range slices.Sorted(maps.Keys(config)) {
+				configs = append(
+					configs,
+					fmt.Sprintf(
+						"%s %s%s",
+						regularStyle.
+							Render("*"),
+						highlightedStyle.
+							Render(key),
+						regularStyle.
+							Render(fmt.Sprintf(" : %s", config[key])),
+					),
+				)
+			}
+			cmd.Println(titleStyle.Render("Global configuration"))
+			cmd.Println()
+			cmd.Println(separator)
+			cmd.Println(strings.Join(configs, "\n"))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGlobalListCmd(t *testing.T) {
+	type scenario struct {
+		name  string
+		setup func(*testing.T) workspaceManager
+		test  func(*testing.T, *bytes.Buffer, *bytes.Buffer, error)
+	}
+	scenarios := []scenario{
+		{
+			"An error occurred when listing the global config",
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("ListGlobalConfig").Return(map[string]string{}, errors.New("an error occurred"))
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			"Listing the global config",
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("ListGlobalConfig").Return(map[string]string{"theme": "dark", "alias-prefix": "c_"}, nil)
+				w.Mock.On("GetConfigDir").Return("/home/user/.config/wo")
+				w.Mock.On("GetStateDir").Return("/home/user/.local/state/wo")
+				w.Mock.On("GetCacheDir").Return("/home/user/.cache/wo")
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, `Global configuration
+
+---
+* alias-prefix : c_
+* cache-dir : /home/user/.cache/wo
+* config-dir : /home/user/.config/wo
+* state-dir : /home/user/.local/state/wo
+* theme : dark
+`, outBuf.String())
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.Setenv("EDITOR", "emacs")
+			os.Setenv("SHELL", "/bin/sh")
+			errBuf := &bytes.Buffer{}
+			outBuf := &bytes.Buffer{}
+			w := s.setup(t)
+			cmd := newGlobalListCmd(w)
+			cmd.SetArgs([]string{})
+			cmd.SetErr(errBuf)
+			cmd.SetOut(outBuf)
+			s.test(t, outBuf, errBuf, cmd.Execute())
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newGlobalSetCmd(workspaceManager workspaceManager, completionManager completionManager) *cobra.Command {
+	return &cobra.Command{
+		Use:               "set key value",
+		Short:             "Set a global configuration",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completionManager.Process,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := workspaceManager.SetGlobalConfig(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			cmd.Printf(
+				regularStyle.Render("Global config key '")+highlightedStyle.Render("%s")+regularStyle.Render("' edited")+"\n",
+				args[0],
+			)
+			return nil
+		},
+	}
+}
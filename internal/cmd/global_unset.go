@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newGlobalUnsetCmd(workspaceManager workspaceManager, completionManager completionManager) *cobra.Command {
+	return &cobra.Command{
+		Use:               "unset key",
+		Short:             "Unset a global configuration, the default value is used instead",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completionManager.Process,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := workspaceManager.UnsetGlobalConfig(args[0])
+			if err != nil {
+				return err
+			}
+			cmd.Printf(
+				regularStyle.Render("Global config key '")+highlightedStyle.Render("%s")+regularStyle.Render("' removed")+"\n",
+				args[0],
+			)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/antham/wo/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+type historyFilter struct {
+	workspace string
+	env       string
+	function  string
+	since     string
+	until     string
+}
+
+func (h historyFilter) filter(entries []workspace.HistoryEntry, now time.Time) ([]workspace.HistoryEntry, error) {
+	var since, until time.Time
+	var err error
+	if h.since != "" {
+		since, err = parseHistoryTime(h.since, now)
+		if err != nil {
+			return []workspace.HistoryEntry{}, err
+		}
+	}
+	if h.until != "" {
+		until, err = parseHistoryTime(h.until, now)
+		if err != nil {
+			return []workspace.HistoryEntry{}, err
+		}
+	}
+	selected := []workspace.HistoryEntry{}
+	for _, e := range entries {
+		switch {
+		case h.workspace != "" && e.Workspace != h.workspace,
+			h.env != "" && e.Env != h.env,
+			h.function != "" && e.Function != h.function,
+			!since.IsZero() && e.Time.Before(since),
+			!until.IsZero() && e.Time.After(until):
+			continue
+		}
+		selected = append(selected, e)
+	}
+	return selected, nil
+}
+
+// parseHistoryTime accepts a duration relative to now or a date
+func parseHistoryTime(value string, now time.Time) (time.Time, error) {
+	d, err := time.ParseDuration(value)
+	if err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err == nil {
+		return t, nil
+	}
+	t, err = time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf(`"%s" is not a valid time, it must be a duration (e.g. 24h) or a date (e.g. 2024-01-31 or 2024-01-31T15:04:05Z)`, value)
+}
+
+func newHistoryCmd(workspaceManager workspaceManager) *cobra.Command {
+	filter := historyFilter{}
+	var limit int
+	var format string
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "List the functions run",
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if limit < 0 {
+				return errors.New("the limit must be greater than or equal to 0")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			entries, err := workspaceManager.GetHistory()
+			if err != nil {
+				return err
+			}
+			entries, err = filter.filter(entries, time.Now())
+			if err != nil {
+				return err
+			}
+			if limit > 0 && len(entries) > limit {
+				entries = entries[len(entries)-limit:]
+			}
+			format, err = resolveOutputFormat(cmd, workspaceManager, format)
+			if err != nil {
+				return err
+			}
+			if format == jsonOutput {
+				outputs := []historyOutput{}
+				for _, e := range entries {
+					outputs = append(outputs, newHistoryOutput(e))
+				}
+				return printJSON(cmd, outputs)
+			}
+			if len(entries) == 0 {
+				return errors.New("no runs match the selection")
+			}
+			var list []string
+			for _, e := range entries {
+				run := strings.Join(append([]string{e.Function}, e.Args...), " ")
+				list = append(
+					list,
+					regularStyle.Render("* ")+
+						highlightedStyle.Render(fmt.Sprint(e.ID))+
+						regularStyle.Render(fmt.Sprintf(" %s %s (%s) %s : exit code %d in %s", e.Time.Local().Format(time.DateTime), e.Workspace, e.Env, run, e.ExitCode, e.Duration.Round(time.Millisecond))),
+				)
+			}
+			cmd.Println(titleStyle.Render("History"))
+			cmd.Println()
+			cmd.Println(separator)
+			cmd.Println(strings.Join(list, "\n"))
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&filter.workspace, "workspace", "w", "", "List the runs of the workspace")
+	cmd.Flags().StringVarP(&filter.env, "env", "e", "", "List the runs in the env")
+	cmd.Flags().StringVarP(&filter.function, "function", "f", "", "List the runs of the function")
+	cmd.Flags().StringVar(&filter.since, "since", "", "List the runs since a duration (e.g. 24h) or a date (e.g. 2024-01-31)")
+	cmd.Flags().StringVar(&filter.until, "until", "", "List the runs until a duration (e.g. 1h) or a date (e.g. 2024-01-31)")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 20, "Maximum number of runs to list, the most recent ones are kept, 0 lists all of them")
+	cmd.Flags().StringVarP(&format, "output", "o", textOutput, "Output format, either text or json, defaults to the global config")
+	return cmd
+}
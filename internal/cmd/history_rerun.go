@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/antham/wo/internal/logger"
+	"github.com/antham/wo/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+func newHistoryRerunCmd(workspaceManager workspaceManager) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rerun id",
+		Short: "Run again a function from the history",
+		Long:  "Run again a function from the history in the same workspace and env and with the same arguments, the id is displayed by the history command",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf(`"%s" is not a valid history id`, args[0])
+			}
+			entries, err := workspaceManager.GetHistory()
+			if err != nil {
+				return err
+			}
+			index := slices.IndexFunc(entries, func(e workspace.HistoryEntry) bool {
+				return e.ID == id
+			})
+			if index == -1 {
+				return fmt.Errorf(`the history entry "%d" does not exist`, id)
+			}
+			entry := entries[index]
+			if slices.Contains(entry.Args, logger.Redacted) {
+				return fmt.Errorf(`the history entry "%d" can't be run again, its sensitive arguments are not recorded`, id)
+			}
+			return exitWithFunctionCode(cmd, workspaceManager.RunFunction(entry.Workspace, entry.Env, append([]string{entry.Function}, entry.Args...)))
+		},
+	}
+}
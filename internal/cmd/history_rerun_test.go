@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/antham/wo/internal/workspace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHistoryRerunCmd(t *testing.T) {
+	type scenario struct {
+		name  string
+		args  []string
+		setup func(*testing.T) workspaceManager
+		test  func(*testing.T, *bytes.Buffer, *bytes.Buffer, error)
+	}
+	entries := []workspace.HistoryEntry{
+		{ID: 1, Workspace: "api", Env: "default", Function: "build", Args: []string{}},
+		{ID: 2, Workspace: "api", Env: "prod", Function: "deploy", Args: []string{"v1", "--force"}},
+		{ID: 3, Workspace: "api", Env: "prod", Function: "login", Args: []string{"admin", "[REDACTED]"}},
+	}
+	scenarios := []scenario{
+		{
+			"An invalid id is provided",
+			[]string{"first"},
+			func(t *testing.T) workspaceManager {
+				return newMockWorkspaceManager(t)
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, `"first" is not a valid history id`)
+			},
+		},
+		{
+			"An error occurred when getting the history",
+			[]string{"1"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetHistory").Return([]workspace.HistoryEntry{}, errors.New("an error occurred"))
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			"The entry does not exist",
+			[]string{"4"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetHistory").Return(entries, nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, `the history entry "4" does not exist`)
+			},
+		},
+		{
+			"The entry has sensitive arguments",
+			[]string{"3"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetHistory").Return(entries, nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, `the history entry "3" can't be run again, its sensitive arguments are not recorded`)
+			},
+		},
+		{
+			"Running again a function",
+			[]string{"2"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetHistory").Return(entries, nil)
+				w.Mock.On("RunFunction", "api", "prod", []string{"deploy", "v1", "--force"}).Return(nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.Setenv("EDITOR", "emacs")
+			os.Setenv("SHELL", "/bin/sh")
+			errBuf := &bytes.Buffer{}
+			outBuf := &bytes.Buffer{}
+			w := s.setup(t)
+			cmd := newHistoryRerunCmd(w)
+			cmd.SetArgs(s.args)
+			cmd.SetErr(errBuf)
+			cmd.SetOut(outBuf)
+			s.test(t, outBuf, errBuf, cmd.Execute())
+		})
+	}
+}
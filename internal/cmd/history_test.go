@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/antham/wo/internal/workspace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHistoryCmd(t *testing.T) {
+	type scenario struct {
+		name  string
+		args  []string
+		setup func(*testing.T) workspaceManager
+		test  func(*testing.T, *bytes.Buffer, *bytes.Buffer, error)
+	}
+	entries := []workspace.HistoryEntry{
+		{ID: 1, Time: time.Date(2024, 1, 30, 10, 0, 0, 0, time.Local), Workspace: "api", Env: "default", Function: "build", Args: []string{}, ExitCode: 0, Duration: 1500 * time.Millisecond, User: "user"},
+		{ID: 2, Time: time.Date(2024, 1, 31, 10, 0, 0, 0, time.Local), Workspace: "api", Env: "prod", Function: "deploy", Args: []string{"v1"}, ExitCode: 2, Duration: 2 * time.Second, User: "user"},
+		{ID: 3, Time: time.Date(2024, 2, 1, 10, 0, 0, 0, time.Local), Workspace: "front", Env: "prod", Function: "deploy", Args: []string{}, ExitCode: 0, Duration: time.Second, User: "user"},
+	}
+	scenarios := []scenario{
+		{
+			"An error occurred when getting the history",
+			[]string{},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetHistory").Return([]workspace.HistoryEntry{}, errors.New("an error occurred"))
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			"Listing the history",
+			[]string{},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetHistory").Return(entries, nil)
+				w.Mock.On("GetGlobalConfig", "output-format").Return("text", nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, `History
+
+---
+* 1 2024-01-30 10:00:00 api (default) build : exit code 0 in 1.5s
+* 2 2024-01-31 10:00:00 api (prod) deploy v1 : exit code 2 in 2s
+* 3 2024-02-01 10:00:00 front (prod) deploy : exit code 0 in 1s
+`, outBuf.String())
+			},
+		},
+		{
+			"Filtering the history",
+			[]string{"-e", "prod", "-f", "deploy", "-w", "api"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetHistory").Return(entries, nil)
+				w.Mock.On("GetGlobalConfig", "output-format").Return("text", nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Contains(t, outBuf.String(), "* 2 ")
+				assert.NotContains(t, outBuf.String(), "* 1 ")
+				assert.NotContains(t, outBuf.String(), "* 3 ")
+			},
+		},
+		{
+			"Filtering the history by time",
+			[]string{"--since", "2024-01-31", "--until", "2024-01-31T12:00:00" + time.Date(2024, 1, 31, 12, 0, 0, 0, time.Local).Format("Z07:00")},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetHistory").Return(entries, nil)
+				w.Mock.On("GetGlobalConfig", "output-format").Return("text", nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Contains(t, outBuf.String(), "* 2 ")
+				assert.NotContains(t, outBuf.String(), "* 1 ")
+				assert.NotContains(t, outBuf.String(), "* 3 ")
+			},
+		},
+		{
+			"Filtering the history with an invalid time",
+			[]string{"--since", "yesterday"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetHistory").Return(entries, nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, `"yesterday" is not a valid time, it must be a duration (e.g. 24h) or a date (e.g. 2024-01-31 or 2024-01-31T15:04:05Z)`)
+			},
+		},
+		{
+			"No runs match the selection",
+			[]string{"--since", "1h"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetHistory").Return(entries, nil)
+				w.Mock.On("GetGlobalConfig", "output-format").Return("text", nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "no runs match the selection")
+			},
+		},
+		{
+			"Limiting the history to the most recent runs",
+			[]string{"-n", "1"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetHistory").Return(entries, nil)
+				w.Mock.On("GetGlobalConfig", "output-format").Return("text", nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Contains(t, outBuf.String(), "* 3 ")
+				assert.NotContains(t, outBuf.String(), "* 2 ")
+			},
+		},
+		{
+			"Using a negative limit",
+			[]string{"-n", "-1"},
+			func(t *testing.T) workspaceManager {
+				return newMockWorkspaceManager(t)
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			"Listing the history as json",
+			[]string{"-w", "front", "-o", "json"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetHistory").Return([]workspace.HistoryEntry{
+					{ID: 3, Time: time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC), Workspace: "front", Env: "prod", Function: "deploy", Cwd: "/tmp", ExitCode: 0, Duration: 1500 * time.Millisecond, User: "user"},
+				}, nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, `[
+  {
+    "id": 3,
+    "time": "2024-02-01T10:00:00Z",
+    "workspace": "front",
+    "env": "prod",
+    "function": "deploy",
+    "args": [],
+    "cwd": "/tmp",
+    "exit_code": 0,
+    "duration": 1.5,
+    "user": "user"
+  }
+]
+`, outBuf.String())
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.Setenv("EDITOR", "emacs")
+			os.Setenv("SHELL", "/bin/sh")
+			errBuf := &bytes.Buffer{}
+			outBuf := &bytes.Buffer{}
+			w := s.setup(t)
+			cmd := newHistoryCmd(w)
+			cmd.SetArgs(s.args)
+			cmd.SetErr(errBuf)
+			cmd.SetOut(outBuf)
+			s.test(t, outBuf, errBuf, cmd.Execute())
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/antham/wo/internal/task"
+	"github.com/spf13/cobra"
+)
+
+func newImportCmd(workspaceManager workspaceManager, completionManager completionManager) *cobra.Command {
+	var from string
+	cmd := &cobra.Command{
+		Use:               "import workspace",
+		Short:             "Import the tasks of a task runner as functions",
+		Long:              "Create a function running each task defined in the Makefile, the package.json scripts, the justfile or the Taskfile found in the path of the workspace, the tasks named like an existing function are skipped",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completionManager.Process,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if from == "" {
+				return errors.New("the --from flag is required")
+			}
+			if !slices.Contains(task.Sources(), from) {
+				return fmt.Errorf(`"%s" is not a supported source, it must be one of: %s`, from, strings.Join(task.Sources(), ", "))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result, err := workspaceManager.ImportFunctions(args[0], from)
+			if err != nil {
+				return err
+			}
+			for _, f := range result.Imported {
+				cmd.Println(regularStyle.Render("Function '") + highlightedStyle.Render(f) + regularStyle.Render("' imported"))
+			}
+			for _, t := range result.Skipped {
+				cmd.Println(regularStyle.Render("Task '") + highlightedStyle.Render(t) + regularStyle.Render("' skipped, a function with the same name already exists"))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&from, "from", "f", "", fmt.Sprintf("Task runner to import the tasks from, one of: %s", strings.Join(task.Sources(), ", ")))
+	return cmd
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/antham/wo/internal/workspace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewImportCmd(t *testing.T) {
+	type scenario struct {
+		name  string
+		args  []string
+		setup func(*testing.T) workspaceManager
+		test  func(*testing.T, *bytes.Buffer, *bytes.Buffer, error)
+	}
+	scenarios := []scenario{
+		{
+			"The source is not provided",
+			[]string{"api"},
+			func(t *testing.T) workspaceManager {
+				return newMockWorkspaceManager(t)
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the --from flag is required")
+			},
+		},
+		{
+			"The source is not supported",
+			[]string{"api", "--from", "gradle"},
+			func(t *testing.T) workspaceManager {
+				return newMockWorkspaceManager(t)
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, `"gradle" is not a supported source, it must be one of: makefile, npm, just, taskfile`)
+			},
+		},
+		{
+			"An error occurred when importing the tasks",
+			[]string{"api", "--from", "npm"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("ImportFunctions", "api", "npm").Return(workspace.ImportResult{}, errors.New("an error occurred"))
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "an error occurred")
+			},
+		},
+		{
+			"Importing the tasks",
+			[]string{"api", "-f", "makefile"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("ImportFunctions", "api", "makefile").Return(workspace.ImportResult{Imported: []string{"build", "db_migrate"}, Skipped: []string{"test"}}, nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "Function 'build' imported\nFunction 'db_migrate' imported\nTask 'test' skipped, a function with the same name already exists\n", outBuf.String())
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.Setenv("EDITOR", "emacs")
+			os.Setenv("SHELL", "/bin/sh")
+			errBuf := &bytes.Buffer{}
+			outBuf := &bytes.Buffer{}
+			cmd := newImportCmd(s.setup(t), newMockCompletionManager(t))
+			cmd.SetArgs(s.args)
+			cmd.SetErr(errBuf)
+			cmd.SetOut(outBuf)
+			s.test(t, outBuf, errBuf, cmd.Execute())
+		})
+	}
+}
@@ -1,25 +1,54 @@
 package cmd
 
 import (
+	"context"
+	"io"
+	"time"
+
 	"github.com/antham/wo/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
 type workspaceManager interface {
-	CreateEnvVariableStatement(string, string) string
-	BuildAliases(string) ([]string, error)
+	CreateEnvVariableStatement(string, string, string) string
+	BuildAliases(string, string) ([]string, error)
 	Get(string) (workspace.Workspace, error)
-	Create(string, string) error
+	Find(string) (workspace.Workspace, error)
+	Create(string, string, string) error
 	CreateEnv(string, string) error
 	Edit(string) error
 	EditEnv(string, string) error
 	Fix() error
 	List() ([]workspace.Workspace, error)
-	RunFunction(string, string, []string) error
+	RunFunction(string, string, []string, ...func(*workspace.RunOptions)) error
+	RunDependencies(string, string, []string, ...func(*workspace.RunOptions)) error
 	Remove(string) error
-	SetConfig(string, map[string]string) error
+	ResolvePath(string) (string, error)
+	ResolveEnv(string, string) (string, error)
+	SetConfig(string, map[string]any) error
+	GetConfig(string, string) (string, error)
+	UnsetConfig(string, string) error
+	AddTags(string, []string) error
+	RemoveTags(string, []string) error
 	GetSupportedApps() []string
+	GetWorkspacesModTime() (time.Time, error)
 	GetConfigDir() string
+	GetLegacyConfigDir() string
+	MigrateConfigDir() (string, error)
+	GetStateDir() string
+	GetCacheDir() string
+	GetGlobalConfigKeys() []string
+	GetGlobalConfig(string) (string, error)
+	GetHistory() ([]workspace.HistoryEntry, error)
+	GetRunPlan(string, string, []string, ...func(*workspace.RunOptions)) (workspace.RunPlan, error)
+	StartJob(string, string, []string, ...func(*workspace.RunOptions)) (workspace.Job, error)
+	ListJobs() ([]workspace.Job, error)
+	StopJob(int) error
+	ReadJobLogs(context.Context, int, int, bool, io.Writer) error
+	ImportFunctions(string, string) (workspace.ImportResult, error)
+	SetGlobalConfig(string, string) error
+	UnsetGlobalConfig(string) error
+	ListGlobalConfig() (map[string]string, error)
 }
 
 type completionManager interface {
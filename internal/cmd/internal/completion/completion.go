@@ -2,8 +2,14 @@ package completion
 
 import (
 	"fmt"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/antham/wo/internal/task"
 	"github.com/spf13/cobra"
 )
 
@@ -50,6 +56,48 @@ func FindWorkspaces(workspaceManager workspaceManager, toComplete string, args .
 	return ws, cobra.ShellCompDirectiveNoFileComp, nil
 }
 
+// FindWorkspacePaths completes the workspace names followed by a slash and
+// then the folders inside the workspace, the workspace is looked up as cd does
+func FindWorkspacePaths(workspaceManager workspaceManager, toComplete string, args ...string) ([]string, cobra.ShellCompDirective, error) {
+	name, subpath, found := strings.Cut(toComplete, "/")
+	if !found {
+		ws, _, err := FindWorkspaces(workspaceManager, toComplete)
+		if err != nil {
+			return []string{}, cobra.ShellCompDirectiveNoFileComp, err
+		}
+		for i := range ws {
+			ws[i] += "/"
+		}
+		return ws, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace, nil
+	}
+	w, err := workspaceManager.Find(name)
+	if err != nil {
+		return []string{}, cobra.ShellCompDirectiveNoFileComp, err
+	}
+	dir, prefix := path.Split(subpath)
+	root := filepath.Join(w.Config["path"], dir)
+	// The folders outside the workspace are refused by cd
+	if rel, err := filepath.Rel(w.Config["path"], root); err != nil || !filepath.IsLocal(rel) {
+		return []string{}, cobra.ShellCompDirectiveNoFileComp, nil
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return []string{}, cobra.ShellCompDirectiveNoFileComp, err
+	}
+	dirs := []string{}
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+		// Hidden folders are only completed when explicitly requested
+		if strings.HasPrefix(e.Name(), ".") && !strings.HasPrefix(prefix, ".") {
+			continue
+		}
+		dirs = append(dirs, fmt.Sprintf("%s/%s%s/", name, dir, e.Name()))
+	}
+	return dirs, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace, nil
+}
+
 func FindFunctions(workspaceManager workspaceManager, toComplete string, args ...string) ([]string, cobra.ShellCompDirective, error) {
 	w, err := workspaceManager.Get(args[0])
 	if err != nil {
@@ -82,6 +130,37 @@ func FindEnvs(workspaceManager workspaceManager, toComplete string, args ...stri
 	return envs, cobra.ShellCompDirectiveNoFileComp, nil
 }
 
+func FindTags(workspaceManager workspaceManager, toComplete string, args ...string) ([]string, cobra.ShellCompDirective, error) {
+	workspaces, err := workspaceManager.List()
+	if err != nil {
+		return []string{}, cobra.ShellCompDirectiveNoFileComp, err
+	}
+	tags := []string{}
+	for _, w := range workspaces {
+		for _, tag := range w.Tags {
+			if strings.HasPrefix(tag, toComplete) && !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	slices.Sort(tags)
+	return tags, cobra.ShellCompDirectiveNoFileComp, nil
+}
+
+func FindWorkspaceTags(workspaceManager workspaceManager, toComplete string, args ...string) ([]string, cobra.ShellCompDirective, error) {
+	w, err := workspaceManager.Get(args[0])
+	if err != nil {
+		return []string{}, cobra.ShellCompDirectiveNoFileComp, err
+	}
+	tags := []string{}
+	for _, tag := range w.Tags {
+		if strings.HasPrefix(tag, toComplete) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, cobra.ShellCompDirectiveNoFileComp, nil
+}
+
 func FindDirs(workspaceManager workspaceManager, toComplete string, args ...string) ([]string, cobra.ShellCompDirective, error) {
 	return []string{}, cobra.ShellCompDirectiveFilterDirs, nil
 }
@@ -103,11 +182,27 @@ var config = map[string]func(workspaceManager, string) ([]string, cobra.ShellCom
 	"path": func(workspaceManager, string) ([]string, cobra.ShellCompDirective, error) {
 		return []string{}, cobra.ShellCompDirectiveFilterDirs, nil
 	},
+	"tags": func(workspaceManager workspaceManager, toComplete string) ([]string, cobra.ShellCompDirective, error) {
+		return FindTags(workspaceManager, toComplete)
+	},
+	"tasks": func(workspaceManager workspaceManager, toComplete string) ([]string, cobra.ShellCompDirective, error) {
+		return filterPrefix(task.Sources(), toComplete), cobra.ShellCompDirectiveNoFileComp, nil
+	},
 }
 
 func FindConfigKey(workspaceManager workspaceManager, toComplete string, args ...string) ([]string, cobra.ShellCompDirective, error) {
+	candidates := slices.Sorted(maps.Keys(config))
+	if len(args) > 0 {
+		w, err := workspaceManager.Get(args[0])
+		if err != nil {
+			return []string{}, cobra.ShellCompDirectiveNoFileComp, err
+		}
+		for _, key := range slices.Sorted(maps.Keys(w.Vars)) {
+			candidates = append(candidates, fmt.Sprintf("vars.%s", key))
+		}
+	}
 	keys := []string{}
-	for key := range config {
+	for _, key := range candidates {
 		if strings.HasPrefix(key, toComplete) {
 			keys = append(keys, key)
 		}
@@ -123,12 +218,31 @@ func FindConfigValue(workspaceManager workspaceManager, toComplete string, args
 	return []string{}, cobra.ShellCompDirectiveNoFileComp, nil
 }
 
+var globalConfig = map[string][]string{
+	"confirm":       {"always", "never"},
+	"notify-method": {"bell", "osc777", "osc9"},
+	"output-format": {"json", "text"},
+	"theme":         {"dark", "light"},
+}
+
 func FindGlobalConfigKey(workspaceManager workspaceManager, toComplete string, args ...string) ([]string, cobra.ShellCompDirective, error) {
-	keys := []string{}
-	for _, key := range []string{"config-dir"} {
-		if strings.HasPrefix(key, toComplete) {
-			keys = append(keys, key)
+	return filterPrefix(append([]string{"cache-dir", "config-dir", "state-dir"}, workspaceManager.GetGlobalConfigKeys()...), toComplete), cobra.ShellCompDirectiveNoFileComp, nil
+}
+
+func FindEditableGlobalConfigKey(workspaceManager workspaceManager, toComplete string, args ...string) ([]string, cobra.ShellCompDirective, error) {
+	return filterPrefix(workspaceManager.GetGlobalConfigKeys(), toComplete), cobra.ShellCompDirectiveNoFileComp, nil
+}
+
+func FindGlobalConfigValue(workspaceManager workspaceManager, toComplete string, args ...string) ([]string, cobra.ShellCompDirective, error) {
+	return filterPrefix(globalConfig[args[0]], toComplete), cobra.ShellCompDirectiveNoFileComp, nil
+}
+
+func filterPrefix(candidates []string, toComplete string) []string {
+	matches := []string{}
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, toComplete) {
+			matches = append(matches, candidate)
 		}
 	}
-	return keys, cobra.ShellCompDirectiveNoFileComp, nil
+	return matches
 }
@@ -2,6 +2,8 @@ package completion
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/antham/wo/internal/workspace"
@@ -138,6 +140,113 @@ func TestFindWorkspaces(t *testing.T) {
 	}
 }
 
+func TestFindWorkspacePaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, d := range []string{"internal/cmd", "internal/config", "docs", ".git"} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, d), 0o777))
+	}
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte{}, 0o666))
+	type scenario struct {
+		name  string
+		setup func(*testing.T) (workspaceManager, string, []string)
+		test  func(*testing.T, []string, cobra.ShellCompDirective, error)
+	}
+	scenarios := []scenario{
+		{
+			"Returns workspaces followed by a slash",
+			func(t *testing.T) (workspaceManager, string, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("List").Return([]workspace.Workspace{{Name: "api"}, {Name: "app"}, {Name: "front"}}, nil)
+				return w, "a", []string{}
+			},
+			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"api/", "app/"}, completion)
+				assert.Equal(t, cobra.ShellCompDirectiveNoFileComp|cobra.ShellCompDirectiveNoSpace, compMode)
+			},
+		},
+		{
+			"An error occurred when getting the workspace",
+			func(t *testing.T) (workspaceManager, string, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("Find", "api").Return(workspace.Workspace{}, errors.New("an error occurred"))
+				return w, "api/", []string{}
+			},
+			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			"Returns the folders of the workspace",
+			func(t *testing.T) (workspaceManager, string, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("Find", "api").Return(workspace.Workspace{Name: "api", Config: map[string]string{"path": dir}}, nil)
+				return w, "api/", []string{}
+			},
+			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"api/docs/", "api/internal/"}, completion)
+				assert.Equal(t, cobra.ShellCompDirectiveNoFileComp|cobra.ShellCompDirectiveNoSpace, compMode)
+			},
+		},
+		{
+			"Returns the nested folders matching the prefix",
+			func(t *testing.T) (workspaceManager, string, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("Find", "api").Return(workspace.Workspace{Name: "api", Config: map[string]string{"path": dir}}, nil)
+				return w, "api/internal/c", []string{}
+			},
+			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"api/internal/cmd/", "api/internal/config/"}, completion)
+			},
+		},
+		{
+			"Returns the folders of the workspace found with a partial name",
+			func(t *testing.T) (workspaceManager, string, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("Find", "ap").Return(workspace.Workspace{Name: "api", Config: map[string]string{"path": dir}}, nil)
+				return w, "ap/d", []string{}
+			},
+			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"ap/docs/"}, completion)
+			},
+		},
+		{
+			"Returns nothing outside the workspace",
+			func(t *testing.T) (workspaceManager, string, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("Find", "api").Return(workspace.Workspace{Name: "api", Config: map[string]string{"path": dir}}, nil)
+				return w, "api/../", []string{}
+			},
+			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, completion)
+			},
+		},
+		{
+			"Returns hidden folders when requested",
+			func(t *testing.T) (workspaceManager, string, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("Find", "api").Return(workspace.Workspace{Name: "api", Config: map[string]string{"path": dir}}, nil)
+				return w, "api/.", []string{}
+			},
+			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"api/.git/"}, completion)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			workspaceManager, toComplete, args := s.setup(t)
+			completion, compMode, err := FindWorkspacePaths(workspaceManager, toComplete, args...)
+			s.test(t, completion, compMode, err)
+		})
+	}
+}
+
 func TestFindEnvs(t *testing.T) {
 	type scenario struct {
 		name  string
@@ -254,6 +363,95 @@ func TestFindFunctions(t *testing.T) {
 	}
 }
 
+func TestFindTags(t *testing.T) {
+	type scenario struct {
+		name  string
+		setup func(*testing.T) (workspaceManager, string, []string)
+		test  func(*testing.T, []string, cobra.ShellCompDirective, error)
+	}
+	scenarios := []scenario{
+		{
+			"An error occurred when listing workspaces",
+			func(t *testing.T) (workspaceManager, string, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("List").Return([]workspace.Workspace{}, errors.New("an error occurred"))
+				return w, "", []string{}
+			},
+			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
+				assert.Error(t, err)
+				assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, compMode)
+			},
+		},
+		{
+			"Returns tags of all workspaces matching the provided prefix",
+			func(t *testing.T) (workspaceManager, string, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("List").Return(
+					[]workspace.Workspace{
+						{Name: "a", Tags: []string{"backend", "go"}},
+						{Name: "b", Tags: []string{"bash", "backend"}},
+						{Name: "c"},
+					}, nil)
+				return w, "ba", []string{}
+			},
+			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"backend", "bash"}, completion)
+				assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, compMode)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			workspaceManager, toComplete, args := s.setup(t)
+			completion, compMode, err := FindTags(workspaceManager, toComplete, args...)
+			s.test(t, completion, compMode, err)
+		})
+	}
+}
+
+func TestFindWorkspaceTags(t *testing.T) {
+	type scenario struct {
+		name  string
+		setup func(*testing.T) (workspaceManager, string, []string)
+		test  func(*testing.T, []string, cobra.ShellCompDirective, error)
+	}
+	scenarios := []scenario{
+		{
+			"An error occurred when getting the workspace",
+			func(t *testing.T) (workspaceManager, string, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("Get", "test").Return(workspace.Workspace{}, errors.New("an error occurred"))
+				return w, "", []string{"test"}
+			},
+			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
+				assert.Error(t, err)
+				assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, compMode)
+			},
+		},
+		{
+			"Returns tags of the workspace matching the provided prefix",
+			func(t *testing.T) (workspaceManager, string, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("Get", "test").Return(workspace.Workspace{Tags: []string{"backend", "bash", "go"}}, nil)
+				return w, "ba", []string{"test"}
+			},
+			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"backend", "bash"}, completion)
+				assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, compMode)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			workspaceManager, toComplete, args := s.setup(t)
+			completion, compMode, err := FindWorkspaceTags(workspaceManager, toComplete, args...)
+			s.test(t, completion, compMode, err)
+		})
+	}
+}
+
 func TestFindDir(t *testing.T) {
 	type scenario struct {
 		name  string
@@ -328,6 +526,31 @@ func TestFindConfigKey(t *testing.T) {
 				assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, compMode)
 			},
 		},
+		{
+			"Returns the config keys and the variables of the workspace",
+			func(t *testing.T) (workspaceManager, string, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("Get", "test").Return(workspace.Workspace{Vars: map[string]string{"port": "8080", "host": "localhost"}}, nil)
+				return w, "", []string{"test"}
+			},
+			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"app", "path", "tags", "tasks", "vars.host", "vars.port"}, completion)
+				assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, compMode)
+			},
+		},
+		{
+			"An error occurred when getting the workspace",
+			func(t *testing.T) (workspaceManager, string, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("Get", "test").Return(workspace.Workspace{}, errors.New("an error occurred"))
+				return w, "", []string{"test"}
+			},
+			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
+				assert.Error(t, err)
+				assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, compMode)
+			},
+		},
 		{
 			"Do no returns a config key not in the allowed config",
 			func(t *testing.T) (workspaceManager, string, []string) {
@@ -380,6 +603,17 @@ func TestFindConfigValue(t *testing.T) {
 				assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, compMode)
 			},
 		},
+		{
+			"Returns supported task runners when config key is tasks",
+			func(t *testing.T) (workspaceManager, string, []string) {
+				return nil, "m", []string{"tasks"}
+			},
+			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"makefile"}, completion)
+				assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, compMode)
+			},
+		},
 		{
 			"Returns nothing if the config key is not defined",
 			func(t *testing.T) (workspaceManager, string, []string) {
@@ -411,18 +645,22 @@ func TestFindGlobalConfigKey(t *testing.T) {
 		{
 			"Returns a config key from the allowed config",
 			func(t *testing.T) (workspaceManager, string, []string) {
-				return nil, "co", []string{}
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetGlobalConfigKeys").Return([]string{"alias-prefix", "confirm", "theme"})
+				return w, "co", []string{}
 			},
 			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
 				assert.NoError(t, err)
-				assert.Equal(t, []string{"config-dir"}, completion)
+				assert.Equal(t, []string{"config-dir", "confirm"}, completion)
 				assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, compMode)
 			},
 		},
 		{
 			"Do no returns a config key not in the allowed config",
 			func(t *testing.T) (workspaceManager, string, []string) {
-				return nil, "x", []string{}
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetGlobalConfigKeys").Return([]string{"alias-prefix", "confirm", "theme"})
+				return w, "x", []string{}
 			},
 			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
 				assert.NoError(t, err)
@@ -439,3 +677,51 @@ func TestFindGlobalConfigKey(t *testing.T) {
 		})
 	}
 }
+
+func TestFindEditableGlobalConfigKey(t *testing.T) {
+	w := newMockWorkspaceManager(t)
+	w.Mock.On("GetGlobalConfigKeys").Return([]string{"alias-prefix", "confirm", "theme"})
+	completion, compMode, err := FindEditableGlobalConfigKey(w, "co")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"confirm"}, completion)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, compMode)
+}
+
+func TestFindGlobalConfigValue(t *testing.T) {
+	type scenario struct {
+		name  string
+		setup func(*testing.T) (workspaceManager, string, []string)
+		test  func(*testing.T, []string, cobra.ShellCompDirective, error)
+	}
+	scenarios := []scenario{
+		{
+			"Returns the values of a key having a fixed set of values",
+			func(t *testing.T) (workspaceManager, string, []string) {
+				return nil, "", []string{"theme"}
+			},
+			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"dark", "light"}, completion)
+				assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, compMode)
+			},
+		},
+		{
+			"Returns nothing for a key accepting any value",
+			func(t *testing.T) (workspaceManager, string, []string) {
+				return nil, "", []string{"editor"}
+			},
+			func(t *testing.T, completion []string, compMode cobra.ShellCompDirective, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{}, completion)
+				assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, compMode)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			workspaceManager, toComplete, args := s.setup(t)
+			completion, compMode, err := FindGlobalConfigValue(workspaceManager, toComplete, args...)
+			s.test(t, completion, compMode, err)
+		})
+	}
+}
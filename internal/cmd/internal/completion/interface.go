@@ -4,7 +4,9 @@ import "github.com/antham/wo/internal/workspace"
 
 type workspaceManager interface {
 	List() ([]workspace.Workspace, error)
+	Find(string) (workspace.Workspace, error)
 	Get(string) (workspace.Workspace, error)
 	GetSupportedApps() []string
 	GetConfigDir() string
+	GetGlobalConfigKeys() []string
 }
@@ -12,6 +12,34 @@ type mockWorkspaceManager struct {
 	mock.Mock
 }
 
+// Find provides a mock function with given fields: _a0
+func (_m *mockWorkspaceManager) Find(_a0 string) (workspace.Workspace, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Find")
+	}
+
+	var r0 workspace.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (workspace.Workspace, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(string) workspace.Workspace); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(workspace.Workspace)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: _a0
 func (_m *mockWorkspaceManager) Get(_a0 string) (workspace.Workspace, error) {
 	ret := _m.Called(_a0)
@@ -58,6 +86,26 @@ func (_m *mockWorkspaceManager) GetConfigDir() string {
 	return r0
 }
 
+// GetGlobalConfigKeys provides a mock function with given fields:
+func (_m *mockWorkspaceManager) GetGlobalConfigKeys() []string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGlobalConfigKeys")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
 // GetSupportedApps provides a mock function with given fields:
 func (_m *mockWorkspaceManager) GetSupportedApps() []string {
 	ret := _m.Called()
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/antham/wo/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+func newJobsCmd(workspaceManager workspaceManager, completionManager completionManager) *cobra.Command {
+	var format string
+	cmd := &cobra.Command{
+		Use:               "jobs [workspace]",
+		Short:             "List the functions running in the background",
+		Long:              "List the functions started with run --detach, in every workspace or in the given one, the jobs which have exited are kept a day so their output can be read",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completionManager.Process,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			jobs, err := workspaceManager.ListJobs()
+			if err != nil {
+				return err
+			}
+			selected := []workspace.Job{}
+			for _, j := range jobs {
+				if len(args) == 0 || j.Workspace == args[0] {
+					selected = append(selected, j)
+				}
+			}
+			format, err = resolveOutputFormat(cmd, workspaceManager, format)
+			if err != nil {
+				return err
+			}
+			if format == jsonOutput {
+				outputs := []jobOutput{}
+				for _, j := range selected {
+					outputs = append(outputs, newJobOutput(j))
+				}
+				return printJSON(cmd, outputs)
+			}
+			if len(selected) == 0 {
+				return errors.New("no jobs are running")
+			}
+			var list []string
+			for _, j := range selected {
+				run := strings.Join(append([]string{j.Function}, j.Args...), " ")
+				status := fmt.Sprintf("running for %s", time.Since(j.Time).Round(time.Second))
+				if j.Exited {
+					status = "exited"
+				}
+				list = append(
+					list,
+					regularStyle.Render("* ")+
+						highlightedStyle.Render(fmt.Sprint(j.ID))+
+						regularStyle.Render(fmt.Sprintf(" %s %s (%s) %s : pid %d, %s", j.Time.Local().Format(time.DateTime), j.Workspace, j.Env, run, j.PID, status)),
+				)
+			}
+			cmd.Println(titleStyle.Render("Jobs"))
+			cmd.Println()
+			cmd.Println(separator)
+			cmd.Println(strings.Join(list, "\n"))
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&format, "output", "o", textOutput, "Output format, either text or json, defaults to the global config")
+	return cmd
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/antham/wo/internal/workspace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJobsCmd(t *testing.T) {
+	type scenario struct {
+		name  string
+		args  []string
+		setup func(*testing.T) workspaceManager
+		test  func(*testing.T, *bytes.Buffer, *bytes.Buffer, error)
+	}
+	jobs := []workspace.Job{
+		{ID: 1, PID: 1234, Time: time.Date(2024, 1, 30, 10, 0, 0, 0, time.Local), Workspace: "api", Env: "default", Function: "serve", Args: []string{"8080"}, Log: "/tmp/jobs/1.log"},
+		{ID: 3, PID: 5678, Time: time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC), Workspace: "front", Env: "prod", Function: "watch", Args: []string{}, Log: "/tmp/jobs/3.log", Exited: true},
+	}
+	scenarios := []scenario{
+		{
+			"An error occurred when listing the jobs",
+			[]string{},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("ListJobs").Return([]workspace.Job{}, errors.New("an error occurred"))
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			"Listing the jobs",
+			[]string{},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("ListJobs").Return(jobs, nil)
+				w.Mock.On("GetGlobalConfig", "output-format").Return("text", nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Regexp(t, `^Jobs
+
+---
+\* 1 2024-01-30 10:00:00 api \(default\) serve 8080 : pid 1234, running for \S+
+\* 3 \S+ \S+ front \(prod\) watch : pid 5678, exited
+$`, outBuf.String())
+			},
+		},
+		{
+			"Listing the jobs of a workspace",
+			[]string{"front"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("ListJobs").Return(jobs, nil)
+				w.Mock.On("GetGlobalConfig", "output-format").Return("text", nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Contains(t, outBuf.String(), "* 3 ")
+				assert.NotContains(t, outBuf.String(), "* 1 ")
+			},
+		},
+		{
+			"No jobs are running",
+			[]string{"db"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("ListJobs").Return(jobs, nil)
+				w.Mock.On("GetGlobalConfig", "output-format").Return("text", nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "no jobs are running")
+			},
+		},
+		{
+			"Listing the jobs as json",
+			[]string{"front", "-o", "json"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("ListJobs").Return(jobs, nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, `[
+  {
+    "id": 3,
+    "pid": 5678,
+    "time": "2024-01-31T10:00:00Z",
+    "workspace": "front",
+    "env": "prod",
+    "function": "watch",
+    "args": [],
+    "log": "/tmp/jobs/3.log",
+    "exited": true
+  }
+]
+`, outBuf.String())
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.Setenv("EDITOR", "emacs")
+			os.Setenv("SHELL", "/bin/sh")
+			errBuf := &bytes.Buffer{}
+			outBuf := &bytes.Buffer{}
+			cmd := newJobsCmd(s.setup(t), newMockCompletionManager(t))
+			cmd.SetArgs(s.args)
+			cmd.SetErr(errBuf)
+			cmd.SetOut(outBuf)
+			s.test(t, outBuf, errBuf, cmd.Execute())
+		})
+	}
+}
@@ -9,7 +9,9 @@ import (
 )
 
 func newListCmd(workspaceManager workspaceManager) *cobra.Command {
-	return &cobra.Command{
+	selector := workspaceSelector{}
+	var format string
+	listCmd := &cobra.Command{
 		Use:   "list",
 		Short: "List workspaces",
 		Args:  cobra.NoArgs,
@@ -21,11 +23,33 @@ func newListCmd(workspaceManager workspaceManager) *cobra.Command {
 			if len(workspaces) == 0 {
 				return errors.New("no workspaces defined")
 			}
+			workspaces, err = selector.filter(workspaces)
+			if err != nil {
+				return err
+			}
+			if len(workspaces) == 0 {
+				return errors.New("no workspaces match the selection")
+			}
+			format, err = resolveOutputFormat(cmd, workspaceManager, format)
+			if err != nil {
+				return err
+			}
+			if format == jsonOutput {
+				outputs := []workspaceOutput{}
+				for _, w := range workspaces {
+					outputs = append(outputs, newWorkspaceOutput(w, false))
+				}
+				return printJSON(cmd, outputs)
+			}
 			title := titleStyle.Render("Workspaces")
 			var list []string
 			for _, w := range workspaces {
+				tags := ""
+				if len(w.Tags) > 0 {
+					tags = fmt.Sprintf(" (%s)", strings.Join(w.Tags, ", "))
+				}
 				list = append(list, regularStyle.
-					Render(fmt.Sprintf("* %s", w.Name)))
+					Render(fmt.Sprintf("* %s%s", w.Name, tags)))
 			}
 			cmd.Println(title)
 			cmd.Println()
@@ -34,4 +58,7 @@ func newListCmd(workspaceManager workspaceManager) *cobra.Command {
 			return nil
 		},
 	}
+	listCmd.Flags().StringSliceVarP(&selector.tags, "tag", "t", []string{}, "List the workspaces having the tag, can be repeated")
+	listCmd.Flags().StringVarP(&format, "output", "o", textOutput, "Output format, either text or json, defaults to the global config")
+	return listCmd
 }
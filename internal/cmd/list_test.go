@@ -53,6 +53,7 @@ func TestNewListCmd(t *testing.T) {
 							Name: "db",
 						},
 					}, nil)
+				w.Mock.On("GetGlobalConfig", "output-format").Return("text", nil)
 				return w
 			},
 			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
@@ -81,3 +82,193 @@ func TestNewListCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestNewListCmdWithTags(t *testing.T) {
+	type scenario struct {
+		name  string
+		args  []string
+		setup func(*testing.T) workspaceManager
+		test  func(*testing.T, *bytes.Buffer, *bytes.Buffer, error)
+	}
+	workspaces := []workspace.Workspace{
+		{Name: "api", Tags: []string{"backend", "go"}},
+		{Name: "db", Tags: []string{"backend"}},
+		{Name: "front"},
+	}
+	scenarios := []scenario{
+		{
+			"Listing workspaces with their tags",
+			[]string{},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("List").Return(workspaces, nil)
+				w.Mock.On("GetGlobalConfig", "output-format").Return("text", nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, `Workspaces
+
+---
+* api (backend, go)
+* db (backend)
+* front
+`, outBuf.String())
+			},
+		},
+		{
+			"Listing workspaces filtered by tags",
+			[]string{"--tag", "backend"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("List").Return(workspaces, nil)
+				w.Mock.On("GetGlobalConfig", "output-format").Return("text", nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, `Workspaces
+
+---
+* api (backend, go)
+* db (backend)
+`, outBuf.String())
+			},
+		},
+		{
+			"No workspaces have the tags",
+			[]string{"--tag", "backend", "--tag", "front"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("List").Return(workspaces, nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "no workspaces match the selection")
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.Setenv("EDITOR", "emacs")
+			os.Setenv("SHELL", "/bin/sh")
+			errBuf := &bytes.Buffer{}
+			outBuf := &bytes.Buffer{}
+			w := s.setup(t)
+			cmd := newListCmd(w)
+			cmd.SetArgs(s.args)
+			cmd.SetErr(errBuf)
+			cmd.SetOut(outBuf)
+			err := cmd.Execute()
+			s.test(t, outBuf, errBuf, err)
+		})
+	}
+}
+
+func TestNewListCmdWithOutput(t *testing.T) {
+	type scenario struct {
+		name  string
+		args  []string
+		setup func(*testing.T) workspaceManager
+		test  func(*testing.T, *bytes.Buffer, *bytes.Buffer, error)
+	}
+	workspaces := []workspace.Workspace{
+		{Name: "api", Config: map[string]string{"app": "bash", "path": "/tmp/api"}, Tags: []string{"backend"}},
+		{Name: "front", Config: map[string]string{"app": "fish", "path": "/tmp/front"}},
+	}
+	expected := `[
+  {
+    "name": "api",
+    "config": {
+      "app": "bash",
+      "path": "/tmp/api"
+    },
+    "tags": [
+      "backend"
+    ]
+  },
+  {
+    "name": "front",
+    "config": {
+      "app": "fish",
+      "path": "/tmp/front"
+    },
+    "tags": []
+  }
+]
+`
+	scenarios := []scenario{
+		{
+			"Listing workspaces as json using the flag",
+			[]string{"-o", "json"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("List").Return(workspaces, nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, expected, outBuf.String())
+			},
+		},
+		{
+			"Listing workspaces as json using the global config",
+			[]string{},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("List").Return(workspaces, nil)
+				w.Mock.On("GetGlobalConfig", "output-format").Return("json", nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, expected, outBuf.String())
+			},
+		},
+		{
+			"The flag takes precedence over the global config",
+			[]string{"--output", "text"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("List").Return(workspaces, nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, `Workspaces
+
+---
+* api (backend)
+* front
+`, outBuf.String())
+			},
+		},
+		{
+			"An unsupported output format",
+			[]string{"-o", "yaml"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("List").Return(workspaces, nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, `"yaml" output format is not supported, must be either "text" or "json"`)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.Setenv("EDITOR", "emacs")
+			os.Setenv("SHELL", "/bin/sh")
+			errBuf := &bytes.Buffer{}
+			outBuf := &bytes.Buffer{}
+			w := s.setup(t)
+			cmd := newListCmd(w)
+			cmd.SetArgs(s.args)
+			cmd.SetErr(errBuf)
+			cmd.SetOut(outBuf)
+			err := cmd.Execute()
+			s.test(t, outBuf, errBuf, err)
+		})
+	}
+}
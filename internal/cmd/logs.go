@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+func newLogsCmd(workspaceManager workspaceManager) *cobra.Command {
+	var lines int
+	var follow bool
+	cmd := &cobra.Command{
+		Use:   "logs id",
+		Short: "Print the output of a function running in the background",
+		Long:  "Print the output of a function started with run --detach, the id is displayed by the jobs command",
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if lines < 0 {
+				return errors.New("the number of lines must be greater than or equal to 0")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseJobID(args[0])
+			if err != nil {
+				return err
+			}
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return workspaceManager.ReadJobLogs(ctx, id, lines, follow, cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().IntVarP(&lines, "lines", "n", 10, "Number of lines to print from the end of the output, 0 prints all of them")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Print the output as it is written until the function ends")
+	return cmd
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewLogsCmd(t *testing.T) {
+	type scenario struct {
+		name  string
+		args  []string
+		setup func(*testing.T) workspaceManager
+		test  func(*testing.T, *bytes.Buffer, *bytes.Buffer, error)
+	}
+	scenarios := []scenario{
+		{
+			"An invalid id is provided",
+			[]string{"first"},
+			func(t *testing.T) workspaceManager {
+				return newMockWorkspaceManager(t)
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, `"first" is not a valid job id`)
+			},
+		},
+		{
+			"A negative number of lines is provided",
+			[]string{"-n", "-1", "1"},
+			func(t *testing.T) workspaceManager {
+				return newMockWorkspaceManager(t)
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the number of lines must be greater than or equal to 0")
+			},
+		},
+		{
+			"Printing the last lines",
+			[]string{"2"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("ReadJobLogs", mock.Anything, 2, 10, false, mock.Anything).Return(func(_ context.Context, _ int, _ int, _ bool, w io.Writer) error {
+					_, err := w.Write([]byte("listening on 8080\n"))
+					return err
+				})
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "listening on 8080\n", outBuf.String())
+			},
+		},
+		{
+			"Following the output",
+			[]string{"-f", "-n", "0", "2"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("ReadJobLogs", mock.Anything, 2, 0, true, mock.Anything).Return(nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"The job does not exist",
+			[]string{"3"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("ReadJobLogs", mock.Anything, 3, 10, false, mock.Anything).Return(errors.New(`the job "3" does not exist`))
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, `the job "3" does not exist`)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.Setenv("EDITOR", "emacs")
+			os.Setenv("SHELL", "/bin/sh")
+			errBuf := &bytes.Buffer{}
+			outBuf := &bytes.Buffer{}
+			cmd := newLogsCmd(s.setup(t))
+			cmd.SetArgs(s.args)
+			cmd.SetErr(errBuf)
+			cmd.SetOut(outBuf)
+			s.test(t, outBuf, errBuf, cmd.Execute())
+		})
+	}
+}
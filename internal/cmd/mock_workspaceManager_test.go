@@ -3,8 +3,13 @@
 package cmd
 
 import (
+	context "context"
+	io "io"
+
 	workspace "github.com/antham/wo/internal/workspace"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // mockWorkspaceManager is an autogenerated mock type for the workspaceManager type
@@ -12,9 +17,27 @@ type mockWorkspaceManager struct {
 	mock.Mock
 }
 
-// BuildAliases provides a mock function with given fields: _a0
-func (_m *mockWorkspaceManager) BuildAliases(_a0 string) ([]string, error) {
-	ret := _m.Called(_a0)
+// AddTags provides a mock function with given fields: _a0, _a1
+func (_m *mockWorkspaceManager) AddTags(_a0 string, _a1 []string) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// BuildAliases provides a mock function with given fields: _a0, _a1
+func (_m *mockWorkspaceManager) BuildAliases(_a0 string, _a1 string) ([]string, error) {
+	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
 		panic("no return value specified for BuildAliases")
@@ -22,19 +45,19 @@ func (_m *mockWorkspaceManager) BuildAliases(_a0 string) ([]string, error) {
 
 	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(string, string) ([]string, error)); ok {
+		return rf(_a0, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(string) []string); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(string, string) []string); ok {
+		r0 = rf(_a0, _a1)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(_a0, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -42,17 +65,17 @@ func (_m *mockWorkspaceManager) BuildAliases(_a0 string) ([]string, error) {
 	return r0, r1
 }
 
-// Create provides a mock function with given fields: _a0, _a1
-func (_m *mockWorkspaceManager) Create(_a0 string, _a1 string) error {
-	ret := _m.Called(_a0, _a1)
+// Create provides a mock function with given fields: _a0, _a1, _a2
+func (_m *mockWorkspaceManager) Create(_a0 string, _a1 string, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Create")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -78,17 +101,17 @@ func (_m *mockWorkspaceManager) CreateEnv(_a0 string, _a1 string) error {
 	return r0
 }
 
-// CreateEnvVariableStatement provides a mock function with given fields: _a0, _a1
-func (_m *mockWorkspaceManager) CreateEnvVariableStatement(_a0 string, _a1 string) string {
-	ret := _m.Called(_a0, _a1)
+// CreateEnvVariableStatement provides a mock function with given fields: _a0, _a1, _a2
+func (_m *mockWorkspaceManager) CreateEnvVariableStatement(_a0 string, _a1 string, _a2 string) string {
+	ret := _m.Called(_a0, _a1, _a2)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateEnvVariableStatement")
 	}
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(string, string, string) string); ok {
+		r0 = rf(_a0, _a1, _a2)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
@@ -132,6 +155,34 @@ func (_m *mockWorkspaceManager) EditEnv(_a0 string, _a1 string) error {
 	return r0
 }
 
+// Find provides a mock function with given fields: _a0
+func (_m *mockWorkspaceManager) Find(_a0 string) (workspace.Workspace, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Find")
+	}
+
+	var r0 workspace.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (workspace.Workspace, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(string) workspace.Workspace); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(workspace.Workspace)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Fix provides a mock function with given fields:
 func (_m *mockWorkspaceManager) Fix() error {
 	ret := _m.Called()
@@ -178,6 +229,52 @@ func (_m *mockWorkspaceManager) Get(_a0 string) (workspace.Workspace, error) {
 	return r0, r1
 }
 
+// GetCacheDir provides a mock function with given fields:
+func (_m *mockWorkspaceManager) GetCacheDir() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCacheDir")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetConfig provides a mock function with given fields: _a0, _a1
+func (_m *mockWorkspaceManager) GetConfig(_a0 string, _a1 string) (string, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetConfig")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetConfigDir provides a mock function with given fields:
 func (_m *mockWorkspaceManager) GetConfigDir() string {
 	ret := _m.Called()
@@ -196,6 +293,155 @@ func (_m *mockWorkspaceManager) GetConfigDir() string {
 	return r0
 }
 
+// GetGlobalConfig provides a mock function with given fields: _a0
+func (_m *mockWorkspaceManager) GetGlobalConfig(_a0 string) (string, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGlobalConfig")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGlobalConfigKeys provides a mock function with given fields:
+func (_m *mockWorkspaceManager) GetGlobalConfigKeys() []string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGlobalConfigKeys")
+	}
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// GetHistory provides a mock function with given fields:
+func (_m *mockWorkspaceManager) GetHistory() ([]workspace.HistoryEntry, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHistory")
+	}
+
+	var r0 []workspace.HistoryEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]workspace.HistoryEntry, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []workspace.HistoryEntry); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]workspace.HistoryEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLegacyConfigDir provides a mock function with given fields:
+func (_m *mockWorkspaceManager) GetLegacyConfigDir() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLegacyConfigDir")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// GetRunPlan provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *mockWorkspaceManager) GetRunPlan(_a0 string, _a1 string, _a2 []string, _a3 ...func(*workspace.RunOptions)) (workspace.RunPlan, error) {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRunPlan")
+	}
+
+	var r0 workspace.RunPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, []string, ...func(*workspace.RunOptions)) (workspace.RunPlan, error)); ok {
+		return rf(_a0, _a1, _a2, _a3...)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, []string, ...func(*workspace.RunOptions)) workspace.RunPlan); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r0 = ret.Get(0).(workspace.RunPlan)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, []string, ...func(*workspace.RunOptions)) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStateDir provides a mock function with given fields:
+func (_m *mockWorkspaceManager) GetStateDir() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStateDir")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // GetSupportedApps provides a mock function with given fields:
 func (_m *mockWorkspaceManager) GetSupportedApps() []string {
 	ret := _m.Called()
@@ -216,6 +462,62 @@ func (_m *mockWorkspaceManager) GetSupportedApps() []string {
 	return r0
 }
 
+// GetWorkspacesModTime provides a mock function with given fields:
+func (_m *mockWorkspaceManager) GetWorkspacesModTime() (time.Time, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspacesModTime")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (time.Time, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportFunctions provides a mock function with given fields: _a0, _a1
+func (_m *mockWorkspaceManager) ImportFunctions(_a0 string, _a1 string) (workspace.ImportResult, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportFunctions")
+	}
+
+	var r0 workspace.ImportResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (workspace.ImportResult, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) workspace.ImportResult); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(workspace.ImportResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields:
 func (_m *mockWorkspaceManager) List() ([]workspace.Workspace, error) {
 	ret := _m.Called()
@@ -246,6 +548,112 @@ func (_m *mockWorkspaceManager) List() ([]workspace.Workspace, error) {
 	return r0, r1
 }
 
+// ListJobs provides a mock function with given fields:
+func (_m *mockWorkspaceManager) ListJobs() ([]workspace.Job, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListJobs")
+	}
+
+	var r0 []workspace.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]workspace.Job, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []workspace.Job); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]workspace.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGlobalConfig provides a mock function with given fields:
+func (_m *mockWorkspaceManager) ListGlobalConfig() (map[string]string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGlobalConfig")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (map[string]string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() map[string]string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MigrateConfigDir provides a mock function with given fields:
+func (_m *mockWorkspaceManager) MigrateConfigDir() (string, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MigrateConfigDir")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (string, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReadJobLogs provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4
+func (_m *mockWorkspaceManager) ReadJobLogs(_a0 context.Context, _a1 int, _a2 int, _a3 bool, _a4 io.Writer) error {
+	ret := _m.Called(_a0, _a1, _a2, _a3, _a4)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadJobLogs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, bool, io.Writer) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3, _a4)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Remove provides a mock function with given fields: _a0
 func (_m *mockWorkspaceManager) Remove(_a0 string) error {
 	ret := _m.Called(_a0)
@@ -264,17 +672,123 @@ func (_m *mockWorkspaceManager) Remove(_a0 string) error {
 	return r0
 }
 
-// RunFunction provides a mock function with given fields: _a0, _a1, _a2
-func (_m *mockWorkspaceManager) RunFunction(_a0 string, _a1 string, _a2 []string) error {
-	ret := _m.Called(_a0, _a1, _a2)
+// RemoveTags provides a mock function with given fields: _a0, _a1
+func (_m *mockWorkspaceManager) RemoveTags(_a0 string, _a1 []string) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResolveEnv provides a mock function with given fields: _a0, _a1
+func (_m *mockWorkspaceManager) ResolveEnv(_a0 string, _a1 string) (string, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveEnv")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResolvePath provides a mock function with given fields: _a0
+func (_m *mockWorkspaceManager) ResolvePath(_a0 string) (string, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolvePath")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RunDependencies provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *mockWorkspaceManager) RunDependencies(_a0 string, _a1 string, _a2 []string, _a3 ...func(*workspace.RunOptions)) error {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunDependencies")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, []string, ...func(*workspace.RunOptions)) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RunFunction provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *mockWorkspaceManager) RunFunction(_a0 string, _a1 string, _a2 []string, _a3 ...func(*workspace.RunOptions)) error {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RunFunction")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, []string) error); ok {
-		r0 = rf(_a0, _a1, _a2)
+	if rf, ok := ret.Get(0).(func(string, string, []string, ...func(*workspace.RunOptions)) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -283,7 +797,7 @@ func (_m *mockWorkspaceManager) RunFunction(_a0 string, _a1 string, _a2 []string
 }
 
 // SetConfig provides a mock function with given fields: _a0, _a1
-func (_m *mockWorkspaceManager) SetConfig(_a0 string, _a1 map[string]string) error {
+func (_m *mockWorkspaceManager) SetConfig(_a0 string, _a1 map[string]interface{}) error {
 	ret := _m.Called(_a0, _a1)
 
 	if len(ret) == 0 {
@@ -291,7 +805,96 @@ func (_m *mockWorkspaceManager) SetConfig(_a0 string, _a1 map[string]string) err
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, map[string]string) error); ok {
+	if rf, ok := ret.Get(0).(func(string, map[string]interface{}) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetGlobalConfig provides a mock function with given fields: _a0, _a1
+func (_m *mockWorkspaceManager) SetGlobalConfig(_a0 string, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetGlobalConfig")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StartJob provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *mockWorkspaceManager) StartJob(_a0 string, _a1 string, _a2 []string, _a3 ...func(*workspace.RunOptions)) (workspace.Job, error) {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartJob")
+	}
+
+	var r0 workspace.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, []string, ...func(*workspace.RunOptions)) (workspace.Job, error)); ok {
+		return rf(_a0, _a1, _a2, _a3...)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, []string, ...func(*workspace.RunOptions)) workspace.Job); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r0 = ret.Get(0).(workspace.Job)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, []string, ...func(*workspace.RunOptions)) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StopJob provides a mock function with given fields: _a0
+func (_m *mockWorkspaceManager) StopJob(_a0 int) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StopJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnsetConfig provides a mock function with given fields: _a0, _a1
+func (_m *mockWorkspaceManager) UnsetConfig(_a0 string, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnsetConfig")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
 		r0 = rf(_a0, _a1)
 	} else {
 		r0 = ret.Error(0)
@@ -300,6 +903,24 @@ func (_m *mockWorkspaceManager) SetConfig(_a0 string, _a1 map[string]string) err
 	return r0
 }
 
+// UnsetGlobalConfig provides a mock function with given fields: _a0
+func (_m *mockWorkspaceManager) UnsetGlobalConfig(_a0 string) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnsetGlobalConfig")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // newMockWorkspaceManager creates a new instance of mockWorkspaceManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func newMockWorkspaceManager(t interface {
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/antham/wo/internal/workspace"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+const (
+	notifyBell   = "bell"
+	notifyOSC9   = "osc9"
+	notifyOSC777 = "osc777"
+)
+
+// notifier tells the user that a run has ended, either with a terminal
+// notification or with a command
+type notifier struct {
+	// after is how long a run must last for to be notified, 0 disables it
+	after   time.Duration
+	method  string
+	command string
+}
+
+var runNotifier = notifier{method: notifyBell}
+
+func configureNotifier(after string, method string, command string) error {
+	runNotifier = notifier{method: method, command: command}
+	if after == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(after)
+	if err != nil {
+		return fmt.Errorf(`"%s" is not a valid value for "%s": %w`, after, workspace.GlobalNotifyAfter, err)
+	}
+	runNotifier.after = d
+	return nil
+}
+
+// notify notifies the end of the run when it lasted longer than the threshold
+// or when it is forced, the failures are only logged as they must not hide
+// the result of the run
+func (n notifier) notify(cmd *cobra.Command, label string, duration time.Duration, err error, force bool) {
+	if !force && (n.after == 0 || duration < n.after) {
+		return
+	}
+	status := "succeeded"
+	switch {
+	case workspace.ExitCode(err) > 0:
+		status = fmt.Sprintf("failed with exit code %d", workspace.ExitCode(err))
+	case err != nil:
+		status = "failed"
+	}
+	message := fmt.Sprintf("%s %s after %s", label, status, duration.Round(time.Second))
+	if n.command != "" {
+		notifyErr := n.runCommand(message, duration, err)
+		if notifyErr != nil {
+			slog.Warn("the notify command failed", "error", notifyErr)
+		}
+		return
+	}
+	notifyErr := n.writeSequence(cmd.ErrOrStderr(), message)
+	if notifyErr != nil {
+		slog.Warn("the notification can't be sent", "error", notifyErr)
+	}
+}
+
+// runCommand runs the notify command with sh, the result of the run is given
+// in environment variables
+func (n notifier) runCommand(message string, duration time.Duration, err error) error {
+	command := exec.Command("sh", "-c", n.command)
+	command.Env = append(
+		os.Environ(),
+		"WO_NOTIFY_MESSAGE="+message,
+		fmt.Sprintf("WO_NOTIFY_EXIT_CODE=%d", workspace.ExitCode(err)),
+		fmt.Sprintf("WO_NOTIFY_DURATION=%d", int(duration.Seconds())),
+	)
+	command.Stdout = os.Stderr
+	command.Stderr = os.Stderr
+	slog.Debug("notify command to run", slog.String("command", n.command))
+	return command.Run()
+}
+
+// writeSequence writes the notification to the terminal, nothing is written
+// when the output is not a terminal so the logs of a CI are not polluted
+func (n notifier) writeSequence(w io.Writer, message string) error {
+	if f, ok := w.(*os.File); ok && !isatty.IsTerminal(f.Fd()) {
+		return nil
+	}
+	// The control characters would end the sequence early
+	message = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, message)
+	var sequence string
+	switch n.method {
+	case notifyOSC9:
+		sequence = fmt.Sprintf("\x1b]9;%s\x07", message)
+	case notifyOSC777:
+		sequence = fmt.Sprintf("\x1b]777;notify;wo;%s\x07", strings.ReplaceAll(message, ";", ","))
+	default:
+		sequence = "\a"
+	}
+	_, err := io.WriteString(w, sequence)
+	return err
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigureNotifier(t *testing.T) {
+	t.Cleanup(func() { runNotifier = notifier{method: notifyBell} })
+	assert.NoError(t, configureNotifier("", "bell", ""))
+	assert.Equal(t, notifier{method: notifyBell}, runNotifier)
+	assert.NoError(t, configureNotifier("2m", "osc9", "notify-send wo"))
+	assert.Equal(t, notifier{after: 2 * time.Minute, method: notifyOSC9, command: "notify-send wo"}, runNotifier)
+	assert.EqualError(t, configureNotifier("later", "bell", ""), `"later" is not a valid value for "notify-after": time: invalid duration "later"`)
+}
+
+func TestNotify(t *testing.T) {
+	exitError := exec.Command("sh", "-c", "exit 3").Run()
+	type scenario struct {
+		name     string
+		notifier notifier
+		duration time.Duration
+		err      error
+		force    bool
+		output   string
+	}
+	scenarios := []scenario{
+		{
+			"The notifications are disabled",
+			notifier{method: notifyBell},
+			time.Hour,
+			nil,
+			false,
+			"",
+		},
+		{
+			"The run is shorter than the threshold",
+			notifier{after: time.Minute, method: notifyBell},
+			30 * time.Second,
+			nil,
+			false,
+			"",
+		},
+		{
+			"The notification is forced",
+			notifier{method: notifyBell},
+			time.Second,
+			nil,
+			true,
+			"\a",
+		},
+		{
+			"Notifying with OSC 9",
+			notifier{after: time.Minute, method: notifyOSC9},
+			2 * time.Minute,
+			exitError,
+			false,
+			"\x1b]9;The function `test` of api failed with exit code 3 after 2m0s\x07",
+		},
+		{
+			"Notifying with OSC 777",
+			notifier{after: time.Minute, method: notifyOSC777},
+			90 * time.Second,
+			errors.New("an error;\x1b occurred"),
+			false,
+			"\x1b]777;notify;wo;The function `test` of api failed after 1m30s\x07",
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			errBuf := &bytes.Buffer{}
+			cmd := &cobra.Command{}
+			cmd.SetErr(errBuf)
+			s.notifier.notify(cmd, "The function `test` of api", s.duration, s.err, s.force)
+			assert.Equal(t, s.output, errBuf.String())
+		})
+	}
+}
+
+func TestNotifyWithCommand(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "notification")
+	errBuf := &bytes.Buffer{}
+	cmd := &cobra.Command{}
+	cmd.SetErr(errBuf)
+	n := notifier{method: notifyBell, command: `printf '%s\n%s\n%s\n' "$WO_NOTIFY_MESSAGE" "$WO_NOTIFY_EXIT_CODE" "$WO_NOTIFY_DURATION" > ` + file}
+	n.notify(cmd, "The function `test` of api", 75*time.Second, nil, true)
+	content, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "The function `test` of api succeeded after 1m15s\n0\n75\n", string(content))
+	assert.Empty(t, errBuf.String())
+}
+
+func TestWriteSequenceOutsideOfTerminal(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "output"))
+	assert.NoError(t, err)
+	defer f.Close()
+	assert.NoError(t, notifier{method: notifyOSC9}.writeSequence(f, "message"))
+	content, err := os.ReadFile(f.Name())
+	assert.NoError(t, err)
+	assert.Empty(t, content)
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/antham/wo/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+const (
+	textOutput = "text"
+	jsonOutput = "json"
+)
+
+type functionOutput struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	DefaultEnv  string   `json:"default_env,omitempty"`
+	Envs        []string `json:"envs,omitempty"`
+	Needs       []string `json:"needs,omitempty"`
+	Source      string   `json:"source,omitempty"`
+}
+
+type workspaceOutput struct {
+	Name      string            `json:"name"`
+	Config    map[string]string `json:"config"`
+	Tags      []string          `json:"tags"`
+	Tasks     []string          `json:"tasks,omitempty"`
+	Vars      map[string]string `json:"vars,omitempty"`
+	Functions []functionOutput  `json:"functions,omitempty"`
+	Envs      []string          `json:"envs,omitempty"`
+}
+
+type historyOutput struct {
+	ID        int       `json:"id"`
+	Time      time.Time `json:"time"`
+	Workspace string    `json:"workspace"`
+	Env       string    `json:"env"`
+	Function  string    `json:"function"`
+	Args      []string  `json:"args"`
+	Cwd       string    `json:"cwd"`
+	ExitCode  int       `json:"exit_code"`
+	Duration  float64   `json:"duration"`
+	User      string    `json:"user"`
+}
+
+type runOutput struct {
+	Workspace string   `json:"workspace"`
+	Env       string   `json:"env"`
+	Function  string   `json:"function"`
+	Args      []string `json:"args"`
+	ExitCode  int      `json:"exit_code"`
+	Duration  float64  `json:"duration"`
+	Stdout    string   `json:"stdout"`
+	Stderr    string   `json:"stderr"`
+	Error     string   `json:"error,omitempty"`
+}
+
+type jobOutput struct {
+	ID        int       `json:"id"`
+	PID       int       `json:"pid"`
+	Time      time.Time `json:"time"`
+	Workspace string    `json:"workspace"`
+	Env       string    `json:"env"`
+	Function  string    `json:"function"`
+	Args      []string  `json:"args"`
+	Log       string    `json:"log"`
+	Exited    bool      `json:"exited"`
+}
+
+func newJobOutput(j workspace.Job) jobOutput {
+	output := jobOutput{
+		ID:        j.ID,
+		PID:       j.PID,
+		Time:      j.Time,
+		Workspace: j.Workspace,
+		Env:       j.Env,
+		Function:  j.Function,
+		Args:      j.Args,
+		Log:       j.Log,
+		Exited:    j.Exited,
+	}
+	if output.Args == nil {
+		output.Args = []string{}
+	}
+	return output
+}
+
+func newHistoryOutput(e workspace.HistoryEntry) historyOutput {
+	output := historyOutput{
+		ID:        e.ID,
+		Time:      e.Time,
+		Workspace: e.Workspace,
+		Env:       e.Env,
+		Function:  e.Function,
+		Args:      e.Args,
+		Cwd:       e.Cwd,
+		ExitCode:  e.ExitCode,
+		Duration:  e.Duration.Seconds(),
+		User:      e.User,
+	}
+	if output.Args == nil {
+		output.Args = []string{}
+	}
+	return output
+}
+
+func newWorkspaceOutput(w workspace.Workspace, withDetails bool) workspaceOutput {
+	output := workspaceOutput{
+		Name:   w.Name,
+		Config: w.Config,
+		Tags:   w.Tags,
+	}
+	if output.Tags == nil {
+		output.Tags = []string{}
+	}
+	if !withDetails {
+		return output
+	}
+	output.Vars = w.Vars
+	output.Tasks = w.Tasks
+	output.Functions = []functionOutput{}
+	for _, f := range w.Functions.Functions {
+		output.Functions = append(output.Functions, functionOutput{
+			Name:        f.Name,
+			Description: f.Description,
+			DefaultEnv:  f.DefaultEnv,
+			Envs:        f.Envs,
+			Needs:       f.Needs,
+			Source:      f.Source,
+		})
+	}
+	output.Envs = []string{}
+	for _, e := range w.Envs {
+		output.Envs = append(output.Envs, e.Name)
+	}
+	return output
+}
+
+// resolveOutputFormat returns the format given with the output flag, or the
+// one defined in the global config when the flag is not provided
+func resolveOutputFormat(cmd *cobra.Command, workspaceManager workspaceManager, format string) (string, error) {
+	if !cmd.Flags().Changed("output") {
+		return workspaceManager.GetGlobalConfig(workspace.GlobalOutputFormat)
+	}
+	if !slices.Contains([]string{textOutput, jsonOutput}, format) {
+		return "", fmt.Errorf(`"%s" output format is not supported, must be either "%s" or "%s"`, format, textOutput, jsonOutput)
+	}
+	return format, nil
+}
+
+func printJSON(cmd *cobra.Command, value any) error {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	cmd.Println(string(content))
+	return nil
+}
+
+type prefixWriter struct {
+	prefix string
+	writer io.Writer
+	mutex  *sync.Mutex
+	buffer []byte
+}
+
+func newPrefixWriter(writer io.Writer, mutex *sync.Mutex, prefix string) *prefixWriter {
+	return &prefixWriter{
+		prefix: prefix,
+		writer: writer,
+		mutex:  mutex,
+	}
+}
+
+func (p *prefixWriter) Write(data []byte) (int, error) {
+	p.buffer = append(p.buffer, data...)
+	for {
+		index := bytes.IndexByte(p.buffer, '\n')
+		if index == -1 {
+			return len(data), nil
+		}
+		err := p.writeLine(p.buffer[:index+1])
+		p.buffer = p.buffer[index+1:]
+		if err != nil {
+			return len(data), err
+		}
+	}
+}
+
+// Flush writes the remaining content not ended by a line break
+func (p *prefixWriter) Flush() error {
+	if len(p.buffer) == 0 {
+		return nil
+	}
+	err := p.writeLine(append(p.buffer, '\n'))
+	p.buffer = nil
+	return err
+}
+
+func (p *prefixWriter) writeLine(line []byte) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	_, err := p.writer.Write(append([]byte(p.prefix), line...))
+	return err
+}
+
+// tailWriter keeps the last bytes written up to its size
+type tailWriter struct {
+	size   int
+	buffer []byte
+}
+
+func newTailWriter(size int) *tailWriter {
+	return &tailWriter{size: size}
+}
+
+func (t *tailWriter) Write(data []byte) (int, error) {
+	t.buffer = append(t.buffer, data...)
+	if len(t.buffer) > t.size {
+		t.buffer = slices.Clone(t.buffer[len(t.buffer)-t.size:])
+	}
+	return len(data), nil
+}
+
+func (t *tailWriter) String() string {
+	return string(t.buffer)
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	mutex := &sync.Mutex{}
+	api := newPrefixWriter(buf, mutex, "[api] ")
+	db := newPrefixWriter(buf, mutex, "[db] ")
+	_, err := api.Write([]byte("line 1\nline"))
+	assert.NoError(t, err)
+	_, err = db.Write([]byte("line 1\n"))
+	assert.NoError(t, err)
+	_, err = api.Write([]byte(" 2\nline 3"))
+	assert.NoError(t, err)
+	assert.NoError(t, api.Flush())
+	assert.NoError(t, db.Flush())
+	assert.Equal(t, "[api] line 1\n[db] line 1\n[api] line 2\n[api] line 3\n", buf.String())
+}
+
+func TestTailWriter(t *testing.T) {
+	w := newTailWriter(8)
+	_, err := w.Write([]byte("line 1\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "line 1\n", w.String())
+	n, err := w.Write([]byte("line 2\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, 7, n)
+	assert.Equal(t, "\nline 2\n", w.String())
+}
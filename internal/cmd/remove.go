@@ -1,16 +1,32 @@
 package cmd
 
 import (
+	"bufio"
+	"errors"
+	"strings"
+
+	"github.com/antham/wo/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
 func newRemoveCmd(workspaceManager workspaceManager, completionManager completionManager) *cobra.Command {
-	return &cobra.Command{
+	var yes bool
+	cmd := &cobra.Command{
 		Use:               "remove workspace",
 		Short:             "Remove a workspace",
+		Long:              `Remove a workspace, a confirmation is asked when the global config "confirm" is set to "always" unless --yes is provided`,
 		Args:              cobra.ExactArgs(1),
 		ValidArgsFunction: completionManager.Process,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if !yes {
+				confirmed, err := confirm(cmd, workspaceManager, regularStyle.Render("Remove workspace '")+highlightedStyle.Render(args[0])+regularStyle.Render("'? [y/N] "))
+				if err != nil {
+					return err
+				}
+				if !confirmed {
+					return errors.New("removal aborted")
+				}
+			}
 			err := workspaceManager.Remove(args[0])
 			if err != nil {
 				return err
@@ -19,4 +35,23 @@ func newRemoveCmd(workspaceManager workspaceManager, completionManager completio
 			return nil
 		},
 	}
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Do not ask for a confirmation")
+	return cmd
+}
+
+// confirm asks the user to confirm an action when the global config requires it
+func confirm(cmd *cobra.Command, workspaceManager workspaceManager, question string) (bool, error) {
+	policy, err := workspaceManager.GetGlobalConfig(workspace.GlobalConfirm)
+	if err != nil {
+		return false, err
+	}
+	if policy != "always" {
+		return true, nil
+	}
+	cmd.Print(question)
+	answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && answer == "" {
+		return false, nil
+	}
+	return strings.EqualFold(strings.TrimSpace(answer), "y") || strings.EqualFold(strings.TrimSpace(answer), "yes"), nil
 }
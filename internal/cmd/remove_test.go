@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,6 +22,7 @@ func TestNewRemoveCmd(t *testing.T) {
 			func(t *testing.T) (workspaceManager, []string) {
 				w := newMockWorkspaceManager(t)
 				args := []string{"api"}
+				w.Mock.On("GetGlobalConfig", "confirm").Return("never", nil)
 				w.Mock.On("Remove", args[0]).Return(errors.New("an error occurred"))
 				return w, args
 			},
@@ -33,6 +35,7 @@ func TestNewRemoveCmd(t *testing.T) {
 			func(t *testing.T) (workspaceManager, []string) {
 				w := newMockWorkspaceManager(t)
 				args := []string{"api"}
+				w.Mock.On("GetGlobalConfig", "confirm").Return("never", nil)
 				w.Mock.On("Remove", args[0]).Return(nil)
 				return w, args
 			},
@@ -57,3 +60,72 @@ func TestNewRemoveCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRemoveCmdWithConfirmation(t *testing.T) {
+	type scenario struct {
+		name  string
+		args  []string
+		stdin string
+		setup func(*testing.T) workspaceManager
+		test  func(*testing.T, *bytes.Buffer, *bytes.Buffer, error)
+	}
+	scenarios := []scenario{
+		{
+			"Removing a workspace after confirming",
+			[]string{"api"},
+			"y\n",
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetGlobalConfig", "confirm").Return("always", nil)
+				w.Mock.On("Remove", "api").Return(nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "Remove workspace 'api'? [y/N] Workspace 'api' deleted\n", outBuf.String())
+			},
+		},
+		{
+			"Aborting the removal of a workspace",
+			[]string{"api"},
+			"\n",
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetGlobalConfig", "confirm").Return("always", nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "removal aborted")
+			},
+		},
+		{
+			"Skipping the confirmation",
+			[]string{"api", "--yes"},
+			"",
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("Remove", "api").Return(nil)
+				return w
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "Workspace 'api' deleted\n", outBuf.String())
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.Setenv("EDITOR", "emacs")
+			os.Setenv("SHELL", "/bin/sh")
+			errBuf := &bytes.Buffer{}
+			outBuf := &bytes.Buffer{}
+			w := s.setup(t)
+			cmd := newRemoveCmd(w, newMockCompletionManager(t))
+			cmd.SetArgs(s.args)
+			cmd.SetIn(strings.NewReader(s.stdin))
+			cmd.SetErr(errBuf)
+			cmd.SetOut(outBuf)
+			s.test(t, outBuf, errBuf, cmd.Execute())
+		})
+	}
+}
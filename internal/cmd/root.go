@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"errors"
+	"io"
 	"log"
 	"log/slog"
 	"os"
+	"strings"
 
 	"github.com/antham/wo/internal/cmd/internal/completion"
+	"github.com/antham/wo/internal/logger"
+	"github.com/antham/wo/internal/task"
 	"github.com/antham/wo/internal/workspace"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -14,33 +18,75 @@ import (
 
 var env string
 
+const (
+	defaultLogMaxSize = 10 * 1024 * 1024
+	logBackups        = 3
+)
+
 func newRootCmd() *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:   "wo",
 		Short: "Manage workspaces in shell",
+		Long:  "Manage workspaces in shell, run without arguments to pick a workspace, a function and an env interactively",
 		CompletionOptions: cobra.CompletionOptions{
 			DisableDefaultCmd: true,
 		},
 	}
 	rootCmd.SetOut(os.Stdout)
 
-	err := viper.BindEnv("WO_DEBUG")
+	for _, key := range []string{"WO_DEBUG", "WO_LOG_FILE", "WO_LOG_MAX_SIZE"} {
+		err := viper.BindEnv(key)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	viper.SetDefault("WO_LOG_MAX_SIZE", defaultLogMaxSize)
+	logLevel := slog.LevelInfo
+	if viper.GetBool("WO_DEBUG") {
+		logLevel = slog.LevelDebug
+	}
+	logOutput, err := newLogOutput()
 	if err != nil {
 		log.Fatal(err)
 	}
-	if viper.GetBool("WO_DEBUG") {
-		slog.SetLogLoggerLevel(slog.LevelDebug)
+	redactor, err := logger.NewRedactor()
+	if err != nil {
+		log.Fatal(err)
 	}
+	setLogger(logOutput, logLevel, redactor)
 
-	err = viper.BindEnv("WO_THEME")
+	w, err := newWorkspaceManager()
 	if err != nil {
 		log.Fatal(err)
 	}
-	if viper.GetString("WO_THEME") == "dark" {
+
+	redactPattern, err := w.GetGlobalConfig(workspace.GlobalRedactPattern)
+	if err != nil {
+		log.Fatal(err)
+	}
+	redactor, err = logger.NewRedactor(redactPattern)
+	if err != nil {
+		log.Fatal(err)
+	}
+	setLogger(logOutput, logLevel, redactor)
+
+	theme, err := w.GetGlobalConfig(workspace.GlobalTheme)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if theme == "dark" {
 		applyDarkTheme()
 	}
 
-	w, err := newWorkspaceManager()
+	notifyConfig := []string{}
+	for _, key := range []string{workspace.GlobalNotifyAfter, workspace.GlobalNotifyMethod, workspace.GlobalNotifyCommand} {
+		value, err := w.GetGlobalConfig(key)
+		if err != nil {
+			log.Fatal(err)
+		}
+		notifyConfig = append(notifyConfig, value)
+	}
+	err = configureNotifier(notifyConfig[0], notifyConfig[1], notifyConfig[2])
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -56,6 +102,11 @@ func newRootCmd() *cobra.Command {
 			completion.FindWorkspaces,
 		},
 	)
+	pathCompMgr := completion.New(
+		w, []completion.Decorator{
+			completion.FindWorkspacePaths,
+		},
+	)
 	funcCompMgr := completion.New(
 		w, []completion.Decorator{
 			completion.FindWorkspaces,
@@ -75,49 +126,166 @@ func newRootCmd() *cobra.Command {
 			completion.FindConfigValue,
 		},
 	)
+	configKeyCompMgr := completion.New(
+		w, []completion.Decorator{
+			completion.FindWorkspaces,
+			completion.FindConfigKey,
+		},
+	)
+	tagAddCompMgr := completion.New(
+		w, []completion.Decorator{
+			completion.FindWorkspaces,
+			completion.FindTags,
+		},
+	)
+	tagRemoveCompMgr := completion.New(
+		w, []completion.Decorator{
+			completion.FindWorkspaces,
+			completion.FindWorkspaceTags,
+		},
+	)
+	tagFlagCompMgr := completion.New(
+		w, []completion.Decorator{
+			completion.FindTags,
+		},
+	)
 	globalGetCompMgr := completion.New(
 		w, []completion.Decorator{
 			completion.FindGlobalConfigKey,
 		},
 	)
+	globalSetCompMgr := completion.New(
+		w, []completion.Decorator{
+			completion.FindEditableGlobalConfigKey,
+			completion.FindGlobalConfigValue,
+		},
+	)
+	globalUnsetCompMgr := completion.New(
+		w, []completion.Decorator{
+			completion.FindEditableGlobalConfigKey,
+		},
+	)
 
 	configCmd := newConfigCmd()
 	configCmd.AddCommand(newConfigSetCmd(w, configSetCompMgr))
+	configCmd.AddCommand(newConfigGetCmd(w, configKeyCompMgr))
+	configCmd.AddCommand(newConfigUnsetCmd(w, configKeyCompMgr))
 
 	globalCmd := newGlobalCmd()
 	globalCmd.AddCommand(newGlobalGetCmd(w, globalGetCompMgr))
+	globalCmd.AddCommand(newGlobalSetCmd(w, globalSetCompMgr))
+	globalCmd.AddCommand(newGlobalListCmd(w))
+	globalCmd.AddCommand(newGlobalUnsetCmd(w, globalUnsetCompMgr))
+
+	tagCmd := newTagCmd()
+	tagCmd.AddCommand(newTagAddCmd(w, tagAddCompMgr))
+	tagCmd.AddCommand(newTagRemoveCmd(w, tagRemoveCompMgr))
+
+	listCmd := newListCmd(w)
+	runCmd := newRunCmd(w, funcCompMgr)
+	for _, c := range []*cobra.Command{listCmd, runCmd} {
+		err = c.RegisterFlagCompletionFunc("tag", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return tagFlagCompMgr.Process(cmd, []string{}, toComplete)
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	historyCmd := newHistoryCmd(w)
+	historyCmd.AddCommand(newHistoryRerunCmd(w))
+	err = historyCmd.RegisterFlagCompletionFunc("workspace", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return wksCompMgr.Process(cmd, []string{}, toComplete)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	createCmd := newCreateCmd(w, dirCompMgr)
+	err = createCmd.RegisterFlagCompletionFunc("app", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return w.GetSupportedApps(), cobra.ShellCompDirectiveNoFileComp
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	importCmd := newImportCmd(w, wksCompMgr)
+	err = importCmd.RegisterFlagCompletionFunc("from", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return task.Sources(), cobra.ShellCompDirectiveNoFileComp
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	envCmd := newEnvCmd()
 	envCmd.AddCommand(newCreateEnvCmd(w, wksCompMgr))
 	envCmd.AddCommand(newEditEnvCmd(w, envCompMgr))
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		warnLegacyConfigDir(cmd, w)
+	}
+	rootCmd.Args = cobra.NoArgs
+	rootCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return runPicker(cmd, w)
+	}
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(envCmd)
 	rootCmd.AddCommand(globalCmd)
+	rootCmd.AddCommand(tagCmd)
 	rootCmd.AddCommand(newSetupCmd(w))
+	rootCmd.AddCommand(newCdCmd(w, pathCompMgr))
 	rootCmd.AddCommand(newFixCmd(w))
-	rootCmd.AddCommand(newCreateCmd(w, dirCompMgr))
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(newJobsCmd(w, wksCompMgr))
+	rootCmd.AddCommand(newLogsCmd(w))
+	rootCmd.AddCommand(newStopCmd(w))
+	rootCmd.AddCommand(createCmd)
 	rootCmd.AddCommand(newEditCmd(w, wksCompMgr))
-	rootCmd.AddCommand(newListCmd(w))
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(newRemoveCmd(w, wksCompMgr))
-	rootCmd.AddCommand(newRunCmd(w, funcCompMgr))
+	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(newShowCmd(w, wksCompMgr))
 	rootCmd.AddCommand(newVersionCmd())
 	return rootCmd
 }
 
+// warnLegacyConfigDir tells the user to move the config folder of the previous
+// versions, the completion and the fix command are left untouched
+func warnLegacyConfigDir(cmd *cobra.Command, workspaceManager workspaceManager) {
+	if strings.HasPrefix(cmd.Name(), "__") || cmd.Name() == "fix" {
+		return
+	}
+	if from := workspaceManager.GetLegacyConfigDir(); from != "" {
+		cmd.PrintErrf("The config folder \"%s\" of a previous version is not used anymore, run \"wo fix\" to move it to \"%s\"\n", from, workspaceManager.GetConfigDir())
+	}
+}
+
+// newLogOutput returns the log file defined with WO_LOG_FILE, the logs are
+// written on stderr otherwise
+func newLogOutput() (io.Writer, error) {
+	file := viper.GetString("WO_LOG_FILE")
+	if file == "" {
+		return os.Stderr, nil
+	}
+	return logger.NewRotatingFile(file, viper.GetInt64("WO_LOG_MAX_SIZE"), logBackups)
+}
+
+// setLogger redacts everything logged, the log package keeps writing on stderr
+// so the fatal errors still reach the user
+func setLogger(output io.Writer, level slog.Level, redactor logger.Redactor) {
+	slog.SetDefault(slog.New(logger.NewHandler(slog.NewTextHandler(output, &slog.HandlerOptions{Level: level}), redactor)))
+	log.SetOutput(os.Stderr)
+	log.SetFlags(log.LstdFlags)
+}
+
 func newWorkspaceManager() (workspaceManager, error) {
-	editor, hasEditor := os.LookupEnv("EDITOR")
-	visual, hasVisual := os.LookupEnv("VISUAL")
 	shell, hasShell := os.LookupEnv("SHELL")
 	configPath, hasConfigPath := os.LookupEnv("WO_CONFIG_PATH")
-	if !hasEditor && !hasVisual {
-		return nil, errors.New("missing EDITOR or VISUAL environment variable")
-	}
 	if !hasShell {
 		return nil, errors.New("missing SHELL environment variable")
 	}
 	options := []func(*workspace.WorkspaceManager){
-		workspace.WithEditor(editor, visual),
+		workspace.WithEditor(os.Getenv("EDITOR"), os.Getenv("VISUAL")),
 		workspace.WithShellPath(shell),
 	}
 	if hasConfigPath {
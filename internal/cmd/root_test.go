@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"bytes"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/antham/wo/internal/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -54,3 +59,79 @@ func TestNewWorkspaceManager(t *testing.T) {
 		})
 	}
 }
+
+func TestNewLogOutput(t *testing.T) {
+	assert.NoError(t, viper.BindEnv("WO_LOG_FILE"))
+	assert.NoError(t, viper.BindEnv("WO_LOG_MAX_SIZE"))
+
+	t.Setenv("WO_LOG_FILE", "")
+	output, err := newLogOutput()
+	assert.NoError(t, err)
+	assert.Equal(t, os.Stderr, output)
+
+	t.Setenv("WO_LOG_FILE", filepath.Join(t.TempDir(), "wo.log"))
+	t.Setenv("WO_LOG_MAX_SIZE", "1024")
+	output, err = newLogOutput()
+	assert.NoError(t, err)
+	assert.IsType(t, &logger.RotatingFile{}, output)
+	assert.NoError(t, output.(*logger.RotatingFile).Close())
+
+	t.Setenv("WO_LOG_MAX_SIZE", "0")
+	_, err = newLogOutput()
+	assert.EqualError(t, err, "the maximum size of the log file must be greater than 0")
+}
+
+func TestWarnLegacyConfigDir(t *testing.T) {
+	type scenario struct {
+		name  string
+		cmd   string
+		setup func(*testing.T) workspaceManager
+		test  func(*testing.T, *bytes.Buffer)
+	}
+	scenarios := []scenario{
+		{
+			"No legacy config folder",
+			"list",
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetLegacyConfigDir").Return("")
+				return w
+			},
+			func(t *testing.T, errBuf *bytes.Buffer) {
+				assert.Empty(t, errBuf.String())
+			},
+		},
+		{
+			"A legacy config folder must be moved",
+			"list",
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetLegacyConfigDir").Return("/home/user/.config/wo")
+				w.Mock.On("GetConfigDir").Return("/tmp/config/wo")
+				return w
+			},
+			func(t *testing.T, errBuf *bytes.Buffer) {
+				assert.Equal(t, "The config folder \"/home/user/.config/wo\" of a previous version is not used anymore, run \"wo fix\" to move it to \"/tmp/config/wo\"\n", errBuf.String())
+			},
+		},
+		{
+			"The completion is left untouched",
+			"__complete",
+			func(t *testing.T) workspaceManager {
+				return newMockWorkspaceManager(t)
+			},
+			func(t *testing.T, errBuf *bytes.Buffer) {
+				assert.Empty(t, errBuf.String())
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			errBuf := &bytes.Buffer{}
+			cmd := &cobra.Command{Use: s.cmd}
+			cmd.SetErr(errBuf)
+			warnLegacyConfigDir(cmd, s.setup(t))
+			s.test(t, errBuf)
+		})
+	}
+}
@@ -1,27 +1,533 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"os/signal"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/antham/wo/internal/watcher"
+	"github.com/antham/wo/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
+type workspaceSelector struct {
+	all   bool
+	match string
+	tags  []string
+}
+
+func (w workspaceSelector) isEnabled() bool {
+	return w.all || w.match != "" || len(w.tags) > 0
+}
+
+func (w workspaceSelector) filter(workspaces []workspace.Workspace) ([]workspace.Workspace, error) {
+	selected := []workspace.Workspace{}
+	for _, wo := range workspaces {
+		if w.match != "" {
+			ok, err := path.Match(w.match, wo.Name)
+			if err != nil {
+				return []workspace.Workspace{}, fmt.Errorf(`"%s" is not a valid pattern`, w.match)
+			}
+			if !ok {
+				continue
+			}
+		}
+		if slices.ContainsFunc(w.tags, func(tag string) bool {
+			return !slices.Contains(wo.Tags, tag)
+		}) {
+			continue
+		}
+		selected = append(selected, wo)
+	}
+	return selected, nil
+}
+
+// outputTailSize is the maximum number of bytes of each output kept in the
+// json result
+const outputTailSize = 64 * 1024
+
+type runResult struct {
+	// name is the workspace or the function the result is about
+	name string
+	err  error
+	// canceled is true when the function was stopped because another one
+	// failed
+	canceled bool
+}
+
+func (r runResult) String() string {
+	var exitError *exec.ExitError
+	switch {
+	case r.err == nil:
+		return "success"
+	case r.canceled:
+		return "canceled"
+	case errors.As(r.err, &exitError) && !errors.Is(r.err, workspace.ErrDependency):
+		return fmt.Sprintf("failed with exit code %d", workspace.ExitCode(r.err))
+	}
+	return r.err.Error()
+}
+
 func newRunCmd(workspaceManager workspaceManager, completionManager completionManager) *cobra.Command {
+	selector := workspaceSelector{}
+	var concurrency int
+	var dryRun, explain, noDeps bool
+	var timeout, retryDelay, debounce time.Duration
+	var retries int
+	var watch, parallel, failFast, detach bool
+	var globs []string
+	var outputFile string
+	var tee, asJSON, notify bool
 	runCmd := &cobra.Command{
-		Use:               "run workspace function [function-args]...",
-		Aliases:           []string{"r"},
-		Short:             "Run a function in a given workspace",
-		Args:              cobra.MinimumNArgs(2),
+		Use:     "run workspace function [function-args]...",
+		Aliases: []string{"r"},
+		Short:   "Run a function in a given workspace",
+		Long:    "Run a function in a given workspace, when one of the flags --all, --match or --tag is provided the workspace argument must be omitted and the function is run in every selected workspace, when the --parallel flag is provided the arguments are the workspace followed by the functions to run at the same time",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if selector.isEnabled() {
+				return cobra.MinimumNArgs(1)(cmd, args)
+			}
+			return cobra.MinimumNArgs(2)(cmd, args)
+		},
 		ValidArgsFunction: completionManager.Process,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if concurrency < 1 {
+				return errors.New("the concurrency must be greater than 0")
+			}
+			if timeout < 0 {
+				return errors.New("the timeout must be greater than or equal to 0")
+			}
+			if retries < 0 {
+				return errors.New("the number of retries must be greater than or equal to 0")
+			}
+			if retryDelay < 0 {
+				return errors.New("the retry delay must be greater than or equal to 0")
+			}
+			if (dryRun || explain) && selector.isEnabled() {
+				return errors.New("the --dry-run and --explain flags can't be used with the --all, --match and --tag flags")
+			}
+			if watch && (dryRun || explain || selector.isEnabled()) {
+				return errors.New("the --watch flag can't be used with the --dry-run, --explain, --all, --match and --tag flags")
+			}
+			if !watch && len(globs) > 0 {
+				return errors.New("the --glob flag can only be used with the --watch flag")
+			}
+			if parallel && (dryRun || explain || watch || selector.isEnabled()) {
+				return errors.New("the --parallel flag can't be used with the --dry-run, --explain, --watch, --all, --match and --tag flags")
+			}
+			if !parallel && failFast {
+				return errors.New("the --fail-fast flag can only be used with the --parallel flag")
+			}
+			if detach && (dryRun || explain || watch || parallel || selector.isEnabled() || timeout > 0 || retries > 0) {
+				return errors.New("the --detach flag can't be used with the --dry-run, --explain, --watch, --parallel, --timeout, --retry, --all, --match and --tag flags")
+			}
+			if (outputFile != "" || asJSON) && (dryRun || explain || watch || parallel || detach || selector.isEnabled()) {
+				return errors.New("the --output-file and --json flags can't be used with the --dry-run, --explain, --watch, --parallel, --detach, --all, --match and --tag flags")
+			}
+			if tee && (outputFile == "" || asJSON) {
+				return errors.New("the --tee flag can only be used with the --output-file flag and without the --json flag")
+			}
+			if notify && (dryRun || explain || watch || detach) {
+				return errors.New("the --notify flag can't be used with the --dry-run, --explain, --watch and --detach flags")
+			}
+			return nil
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			err := workspaceManager.RunFunction(args[0], env, args[1:])
-			if exitError, ok := err.(*exec.ExitError); ok {
-				os.Exit(exitError.ExitCode())
+			options := []func(*workspace.RunOptions){}
+			if noDeps {
+				options = append(options, workspace.WithoutDependencies())
 			}
-			return err
+			if dryRun || explain {
+				return printRunPlan(cmd, workspaceManager, args, dryRun, explain, options...)
+			}
+			if detach {
+				return startJob(cmd, workspaceManager, args, options...)
+			}
+			options = append(
+				options,
+				workspace.WithTimeout(timeout),
+				workspace.WithRetry(retries, retryDelay),
+			)
+			if watch {
+				ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+				defer stop()
+				return runWatching(ctx, cmd, workspaceManager, args, globs, debounce, options...)
+			}
+			start := time.Now()
+			var err error
+			var label string
+			switch {
+			case selector.isEnabled():
+				label = fmt.Sprintf("The function `%s` in the selected workspaces", args[0])
+				err = runInWorkspaces(cmd, workspaceManager, selector, concurrency, args, options...)
+			case parallel:
+				label = fmt.Sprintf("The functions `%s` of %s", strings.Join(args[1:], "`, `"), args[0])
+				err = runInParallel(cmd, workspaceManager, args[0], args[1:], failFast, options...)
+			case outputFile != "" || asJSON:
+				label = fmt.Sprintf("The function `%s` of %s", args[1], args[0])
+				err = runCapturingOutput(cmd, workspaceManager, args, outputFile, tee, asJSON, options...)
+			default:
+				label = fmt.Sprintf("The function `%s` of %s", args[1], args[0])
+				err = workspaceManager.RunFunction(args[0], env, args[1:], options...)
+			}
+			runNotifier.notify(cmd, label, time.Since(start), err, notify)
+			return exitWithFunctionCode(cmd, err)
 		},
 	}
-	runCmd.Flags().StringVarP(&env, "env", "e", "default", "Environment to use (e.g. prod)")
+	runCmd.Flags().StringVarP(&env, "env", "e", "", "Environment to use (e.g. prod), defaults to the env declared by the function or to the default env")
+	runCmd.Flags().BoolVarP(&selector.all, "all", "a", false, "Run the function in all workspaces")
+	runCmd.Flags().StringVarP(&selector.match, "match", "m", "", "Run the function in the workspaces whose name matches the glob pattern (e.g. 'api-*')")
+	runCmd.Flags().StringSliceVarP(&selector.tags, "tag", "t", []string{}, "Run the function in the workspaces having the tag, can be repeated")
+	runCmd.Flags().IntVarP(&concurrency, "concurrency", "j", 4, "Maximum number of workspaces running the function at the same time")
+	runCmd.Flags().DurationVar(&timeout, "timeout", 0, fmt.Sprintf("Stop the function when it runs for longer than the duration (e.g. 30s, 5m), it exits with the code %d", workspace.TimeoutExitCode))
+	runCmd.Flags().IntVar(&retries, "retry", 0, "Number of times the function is run again when it fails or times out")
+	runCmd.Flags().DurationVar(&retryDelay, "retry-delay", time.Second, "Time to wait before running again a failing function")
+	runCmd.Flags().BoolVar(&noDeps, "no-deps", false, "Don't run first the functions needed by the function")
+	runCmd.Flags().BoolVarP(&detach, "detach", "d", false, "Run the function in the background, its output is written in a log read with the logs command")
+	runCmd.Flags().BoolVar(&parallel, "parallel", false, "Run the functions given as arguments at the same time, their output is prefixed with their name")
+	runCmd.Flags().BoolVar(&failFast, "fail-fast", false, "Stop the other functions run with --parallel as soon as one fails")
+	runCmd.Flags().BoolVar(&watch, "watch", false, "Run the function again each time a file of the workspace changes, the files ignored by git are skipped")
+	runCmd.Flags().StringSliceVar(&globs, "glob", []string{}, "Only watch the files matching the glob (e.g. 'src/**/*.go' or '*.go' for the go files of any folder), can be repeated")
+	runCmd.Flags().DurationVar(&debounce, "debounce", 300*time.Millisecond, "Time without changes to wait for before running again the function")
+	runCmd.Flags().StringVar(&outputFile, "output-file", "", "Write the output of the function in the file instead of the terminal")
+	runCmd.Flags().BoolVar(&tee, "tee", false, "Write the output of the function in the terminal as well when --output-file is provided")
+	runCmd.Flags().BoolVar(&asJSON, "json", false, fmt.Sprintf("Print the result of the run as json once the function ends, with the last %dKiB of its output", outputTailSize/1024))
+	runCmd.Flags().BoolVar(&notify, "notify", false, "Notify when the function ends whatever its duration, the notify-after global config notifies only the long runs")
+	runCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the script running the function instead of running it")
+	runCmd.Flags().BoolVar(&explain, "explain", false, "Print the env, the exported variables and the files sourced in order instead of running the function")
 	return runCmd
 }
+
+// printRunPlan prints how the function would be run, the script is printed
+// without style so it can be copied and pasted
+func printRunPlan(cmd *cobra.Command, workspaceManager workspaceManager, functionAndArgs []string, dryRun bool, explain bool, options ...func(*workspace.RunOptions)) error {
+	plan, err := workspaceManager.GetRunPlan(functionAndArgs[0], env, functionAndArgs[1:], options...)
+	if err != nil {
+		return err
+	}
+	if explain {
+		item := func(key string, value string) string {
+			return fmt.Sprintf(
+				"%s %s%s",
+				regularStyle.
+					Render("*"),
+				highlightedStyle.
+					Render(key),
+				regularStyle.
+					Render(fmt.Sprintf(" : %s", value)),
+			)
+		}
+		runs := []string{
+			item("workspace", plan.Workspace),
+			item("env", plan.Env),
+			item("function", plan.Function),
+			item("shell", plan.Shell),
+			item("path", plan.Path),
+		}
+		if len(plan.Dependencies) > 0 {
+			var dependencies []string
+			for _, d := range plan.Dependencies {
+				dependencies = append(dependencies, d.Function)
+			}
+			runs = append(runs, item("dependencies", strings.Join(dependencies, ", ")))
+		}
+		var variables []string
+		for _, v := range plan.Variables {
+			variables = append(variables, item(v.Name, v.Value))
+		}
+		var files []string
+		for i, f := range plan.Files {
+			files = append(files, item(fmt.Sprint(i+1), f))
+		}
+		cmd.Println(titleStyle.Render("Run"))
+		cmd.Println()
+		cmd.Println(strings.Join(runs, "\n"))
+		cmd.Println()
+		cmd.Println(separator)
+		cmd.Println(titleStyle.Render("Exported variables"))
+		cmd.Println()
+		cmd.Println(strings.Join(variables, "\n"))
+		cmd.Println()
+		cmd.Println(separator)
+		cmd.Println(titleStyle.Render("Sourced files"))
+		cmd.Println()
+		cmd.Println(strings.Join(files, "\n"))
+		cmd.Println()
+		cmd.Println(separator)
+	}
+	if dryRun {
+		var scripts []string
+		for _, d := range plan.Dependencies {
+			scripts = append(scripts, d.Script())
+		}
+		scripts = append(scripts, plan.Script())
+		_, err = io.WriteString(cmd.OutOrStdout(), strings.Join(scripts, "\n")+"\n")
+	}
+	return err
+}
+
+// startJob runs the function in the background once its dependencies are
+// done
+func startJob(cmd *cobra.Command, workspaceManager workspaceManager, functionAndArgs []string, options ...func(*workspace.RunOptions)) error {
+	job, err := workspaceManager.StartJob(functionAndArgs[0], env, functionAndArgs[1:], options...)
+	if err != nil {
+		return exitWithFunctionCode(cmd, err)
+	}
+	cmd.Println(regularStyle.Render(fmt.Sprintf(`The function runs in the background as the job %d, run "wo logs %d" to read its output and "wo stop %d" to stop it`, job.ID, job.ID, job.ID)))
+	return nil
+}
+
+// runCapturingOutput runs the function with its output written in a file, in
+// the result printed as json or in both
+func runCapturingOutput(cmd *cobra.Command, workspaceManager workspaceManager, functionAndArgs []string, outputFile string, tee bool, asJSON bool, options ...func(*workspace.RunOptions)) error {
+	stdouts := []io.Writer{}
+	stderrs := []io.Writer{}
+	if outputFile != "" {
+		f, err := os.Create(outputFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		stdouts = append(stdouts, f)
+		stderrs = append(stderrs, f)
+	}
+	if tee {
+		stdouts = append(stdouts, cmd.OutOrStdout())
+		stderrs = append(stderrs, cmd.ErrOrStderr())
+	}
+	if !asJSON {
+		return workspaceManager.RunFunction(functionAndArgs[0], env, functionAndArgs[1:], append(options, workspace.WithOutput(io.MultiWriter(stdouts...), io.MultiWriter(stderrs...)))...)
+	}
+	// The plan gives the env the function is run in when none is provided
+	plan, err := workspaceManager.GetRunPlan(functionAndArgs[0], env, functionAndArgs[1:])
+	if err != nil {
+		return err
+	}
+	stdout := newTailWriter(outputTailSize)
+	stderr := newTailWriter(outputTailSize)
+	stdouts = append(stdouts, stdout)
+	stderrs = append(stderrs, stderr)
+	start := time.Now()
+	err = workspaceManager.RunFunction(functionAndArgs[0], env, functionAndArgs[1:], append(options, workspace.WithOutput(io.MultiWriter(stdouts...), io.MultiWriter(stderrs...)))...)
+	output := runOutput{
+		Workspace: plan.Workspace,
+		Env:       plan.Env,
+		Function:  plan.Function,
+		Args:      plan.FunctionArgs,
+		ExitCode:  workspace.ExitCode(err),
+		Duration:  time.Since(start).Seconds(),
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+	}
+	if err != nil {
+		output.Error = err.Error()
+	}
+	printErr := printJSON(cmd, output)
+	if printErr != nil {
+		return printErr
+	}
+	// The error is already part of the result, only the exit code is left
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return err
+}
+
+// exitWithFunctionCode exits with the exit code of the function when it or
+// one of its dependencies failed or timed out, the other errors are returned
+func exitWithFunctionCode(cmd *cobra.Command, err error) error {
+	var exitError *exec.ExitError
+	switch {
+	case errors.Is(err, workspace.ErrTimeout) || errors.Is(err, workspace.ErrDependency) && errors.As(err, &exitError):
+		cmd.PrintErrln("Error:", err)
+		os.Exit(workspace.ExitCode(err))
+	case errors.As(err, &exitError):
+		os.Exit(workspace.ExitCode(err))
+	}
+	return err
+}
+
+// runWatching runs the function again each time the files of the workspace
+// change, a run still going is stopped first, it stops when the context is
+// done
+func runWatching(ctx context.Context, cmd *cobra.Command, workspaceManager workspaceManager, functionAndArgs []string, globs []string, debounce time.Duration, options ...func(*workspace.RunOptions)) error {
+	wo, err := workspaceManager.Get(functionAndArgs[0])
+	if err != nil {
+		return err
+	}
+	w, err := watcher.New(wo.Config["path"], globs, debounce)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	for {
+		runCtx, cancel := context.WithCancel(ctx)
+		done := make(chan error, 1)
+		go func() {
+			done <- workspaceManager.RunFunction(functionAndArgs[0], env, functionAndArgs[1:], append(options, workspace.WithContext(runCtx))...)
+		}()
+		var files []string
+		select {
+		case err := <-done:
+			var exitError *exec.ExitError
+			if err != nil && !errors.Is(err, workspace.ErrTimeout) && !errors.As(err, &exitError) {
+				cancel()
+				return err
+			}
+			status := "succeeded"
+			if err != nil {
+				status = fmt.Sprintf("failed with exit code %d", workspace.ExitCode(err))
+			}
+			cmd.PrintErrln(regularStyle.Render(fmt.Sprintf("The function %s, waiting for changes", status)))
+			// The result is put back as the run is waited for below
+			done <- err
+			select {
+			case files = <-w.Changes():
+			case <-ctx.Done():
+			}
+		case files = <-w.Changes():
+		case <-ctx.Done():
+		}
+		cancel()
+		<-done
+		if ctx.Err() != nil {
+			return nil
+		}
+		if len(files) > 3 {
+			files = append(files[:3], fmt.Sprintf("%d more", len(files)-3))
+		}
+		cmd.PrintErrln(regularStyle.Render(fmt.Sprintf("Changes detected in %s, running the function again", strings.Join(files, ", "))))
+	}
+}
+
+func runInWorkspaces(cmd *cobra.Command, workspaceManager workspaceManager, selector workspaceSelector, concurrency int, functionAndArgs []string, options ...func(*workspace.RunOptions)) error {
+	workspaces, err := workspaceManager.List()
+	if err != nil {
+		return err
+	}
+	workspaces, err = selector.filter(workspaces)
+	if err != nil {
+		return err
+	}
+	if len(workspaces) == 0 {
+		return errors.New("no workspaces match the selection")
+	}
+	results := make([]runResult, len(workspaces))
+	semaphore := make(chan struct{}, concurrency)
+	mutex := &sync.Mutex{}
+	var wg sync.WaitGroup
+	for i, w := range workspaces {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			prefix := highlightedStyle.Render(fmt.Sprintf("[%s]", w.Name)) + " "
+			stdout := newPrefixWriter(cmd.OutOrStdout(), mutex, prefix)
+			stderr := newPrefixWriter(cmd.ErrOrStderr(), mutex, prefix)
+			err := workspaceManager.RunFunction(w.Name, env, functionAndArgs, append(options, workspace.WithOutput(stdout, stderr))...)
+			results[i] = runResult{
+				name: w.Name,
+				err:  errors.Join(err, stdout.Flush(), stderr.Flush()),
+			}
+		}()
+	}
+	wg.Wait()
+	failures := printRunSummary(cmd, results)
+	if failures > 0 {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("the function failed in %d workspace(s) out of %d", failures, len(results))
+	}
+	return nil
+}
+
+// runInParallel runs the functions of the workspace at the same time in the
+// same env, it returns the error of the first failing function, the other
+// functions are stopped on the first failure when failFast is true, the
+// dependencies they share are run once before them
+func runInParallel(cmd *cobra.Command, workspaceManager workspaceManager, name string, functions []string, failFast bool, options ...func(*workspace.RunOptions)) error {
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+	err := workspaceManager.RunDependencies(name, env, functions, append(options, workspace.WithOutput(cmd.OutOrStdout(), cmd.ErrOrStderr()), workspace.WithContext(ctx))...)
+	if err != nil {
+		cmd.SilenceUsage = true
+		return err
+	}
+	options = append(options, workspace.WithoutDependencies())
+	width := 0
+	for _, f := range functions {
+		width = max(width, len(f))
+	}
+	results := make([]runResult, len(functions))
+	outputMutex := &sync.Mutex{}
+	resultsMutex := &sync.Mutex{}
+	var firstErr error
+	var wg sync.WaitGroup
+	for i, f := range functions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			prefix := prefixStyles[i%len(prefixStyles)].Render(fmt.Sprintf("[%s]%s", f, strings.Repeat(" ", width-len(f)))) + " "
+			stdout := newPrefixWriter(cmd.OutOrStdout(), outputMutex, prefix)
+			stderr := newPrefixWriter(cmd.ErrOrStderr(), outputMutex, prefix)
+			err := workspaceManager.RunFunction(name, env, []string{f}, append(options, workspace.WithOutput(stdout, stderr), workspace.WithContext(ctx))...)
+			err = errors.Join(err, stdout.Flush(), stderr.Flush())
+			resultsMutex.Lock()
+			defer resultsMutex.Unlock()
+			results[i] = runResult{
+				name:     f,
+				err:      err,
+				canceled: errors.Is(err, context.Canceled),
+			}
+			if err != nil && firstErr == nil {
+				firstErr = err
+				if failFast {
+					cancel()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	printRunSummary(cmd, results)
+	cmd.SilenceUsage = firstErr != nil
+	return firstErr
+}
+
+// printRunSummary prints the result of each run and returns the number of
+// failures
+func printRunSummary(cmd *cobra.Command, results []runResult) int {
+	var summary []string
+	failures := 0
+	for _, r := range results {
+		if r.err != nil {
+			failures++
+		}
+		summary = append(
+			summary,
+			fmt.Sprintf(
+				"%s %s%s",
+				regularStyle.
+					Render("*"),
+				highlightedStyle.
+					Render(r.name),
+				regularStyle.
+					Render(fmt.Sprintf(" : %s", r)),
+			),
+		)
+	}
+	cmd.Println()
+	cmd.Println(titleStyle.Render("Summary"))
+	cmd.Println()
+	cmd.Println(separator)
+	cmd.Println(strings.Join(summary, "\n"))
+	return failures
+}
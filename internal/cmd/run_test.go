@@ -2,11 +2,19 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/antham/wo/internal/workspace"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestNewRunCmd(t *testing.T) {
@@ -21,19 +29,98 @@ func TestNewRunCmd(t *testing.T) {
 			func(t *testing.T) (workspaceManager, []string) {
 				w := newMockWorkspaceManager(t)
 				args := []string{"api", "start"}
-				w.Mock.On("RunFunction", args[0], "default", []string{args[1]}).Return(nil)
+				w.Mock.On("RunFunction", args[0], "", []string{args[1]}, mock.Anything, mock.Anything).Return(nil)
 				return w, args
 			},
 			func(t *testing.T, err error) {
 				assert.NoError(t, err)
 			},
 		},
+		{
+			"Running a function in a given env",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				args := []string{"-e", "prod", "api", "start"}
+				w.Mock.On("RunFunction", args[2], "prod", []string{args[3]}, mock.Anything, mock.Anything).Return(nil)
+				return w, args
+			},
+			func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"Running a function with a timeout and retries",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				args := []string{"--timeout", "1m", "--retry", "2", "--retry-delay", "5s", "api", "start"}
+				w.Mock.On("RunFunction", "api", "", []string{"start"}, mock.Anything, mock.Anything).Return(func(name string, env string, functionAndArgs []string, options ...func(*workspace.RunOptions)) error {
+					runOptions := &workspace.RunOptions{}
+					for _, o := range options {
+						o(runOptions)
+					}
+					assert.Equal(t, time.Minute, runOptions.Timeout)
+					assert.Equal(t, 2, runOptions.Retries)
+					assert.Equal(t, 5*time.Second, runOptions.RetryDelay)
+					return nil
+				})
+				return w, args
+			},
+			func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"Running a function without its dependencies",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				args := []string{"--no-deps", "api", "deploy"}
+				w.Mock.On("RunFunction", "api", "", []string{"deploy"}, mock.Anything, mock.Anything, mock.Anything).Return(func(name string, env string, functionAndArgs []string, options ...func(*workspace.RunOptions)) error {
+					runOptions := &workspace.RunOptions{}
+					for _, o := range options {
+						o(runOptions)
+					}
+					assert.True(t, runOptions.NoDeps)
+					return nil
+				})
+				return w, args
+			},
+			func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"Running a function with a negative number of retries",
+			func(t *testing.T) (workspaceManager, []string) {
+				return newMockWorkspaceManager(t), []string{"--retry", "-1", "api", "start"}
+			},
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, "the number of retries must be greater than or equal to 0")
+			},
+		},
+		{
+			"Running a function with a negative timeout",
+			func(t *testing.T) (workspaceManager, []string) {
+				return newMockWorkspaceManager(t), []string{"--timeout", "-1s", "api", "start"}
+			},
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, "the timeout must be greater than or equal to 0")
+			},
+		},
+		{
+			"Running a function with the notify and watch flags",
+			func(t *testing.T) (workspaceManager, []string) {
+				return newMockWorkspaceManager(t), []string{"--notify", "--watch", "api", "start"}
+			},
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, "the --notify flag can't be used with the --dry-run, --explain, --watch and --detach flags")
+			},
+		},
 		{
 			"Running a function with an error",
 			func(t *testing.T) (workspaceManager, []string) {
 				w := newMockWorkspaceManager(t)
 				args := []string{"api", "start"}
-				w.Mock.On("RunFunction", args[0], "default", []string{args[1]}).Return(errors.New("an error occurred"))
+				w.Mock.On("RunFunction", args[0], "", []string{args[1]}, mock.Anything, mock.Anything).Return(errors.New("an error occurred"))
 				return w, args
 			},
 			func(t *testing.T, err error) {
@@ -54,3 +141,734 @@ func TestNewRunCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestNewRunCmdInWorkspaces(t *testing.T) {
+	type scenario struct {
+		name  string
+		setup func(*testing.T) (workspaceManager, []string)
+		test  func(*testing.T, *bytes.Buffer, *bytes.Buffer, error)
+	}
+	workspaces := []workspace.Workspace{
+		{Name: "api", Tags: []string{"backend", "go"}},
+		{Name: "api-admin", Tags: []string{"backend"}},
+		{Name: "front", Tags: []string{"frontend"}},
+	}
+	writeOutput := func(stdout string, stderr string, err error) func(string, string, []string, ...func(*workspace.RunOptions)) error {
+		return func(name string, env string, functionAndArgs []string, options ...func(*workspace.RunOptions)) error {
+			runOptions := &workspace.RunOptions{}
+			for _, o := range options {
+				o(runOptions)
+			}
+			_, werr := runOptions.Stdout.Write([]byte(stdout))
+			assert.NoError(t, werr)
+			_, werr = runOptions.Stderr.Write([]byte(stderr))
+			assert.NoError(t, werr)
+			return err
+		}
+	}
+	scenarios := []scenario{
+		{
+			"Running a function in all workspaces",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("List").Return(workspaces, nil)
+				w.Mock.On("RunFunction", "api", "", []string{"git_pull", "origin"}, mock.Anything, mock.Anything, mock.Anything).Return(writeOutput("api output\n", "", nil))
+				w.Mock.On("RunFunction", "api-admin", "", []string{"git_pull", "origin"}, mock.Anything, mock.Anything, mock.Anything).Return(writeOutput("api-admin output", "", nil))
+				w.Mock.On("RunFunction", "front", "", []string{"git_pull", "origin"}, mock.Anything, mock.Anything, mock.Anything).Return(writeOutput("", "front error\n", nil))
+				return w, []string{"--all", "git_pull", "origin"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Contains(t, outBuf.String(), "[api] api output\n")
+				assert.Contains(t, outBuf.String(), "[api-admin] api-admin output\n")
+				assert.Equal(t, "[front] front error\n", errBuf.String())
+				assert.Contains(t, outBuf.String(), `Summary
+
+---
+* api : success
+* api-admin : success
+* front : success
+`)
+			},
+		},
+		{
+			"Running a function in workspaces matching a pattern and a tag",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("List").Return(workspaces, nil)
+				w.Mock.On("RunFunction", "api", "prod", []string{"test"}, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				return w, []string{"--match", "api*", "--tag", "go", "-e", "prod", "test"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Contains(t, outBuf.String(), "* api : success\n")
+				assert.NotContains(t, outBuf.String(), "api-admin")
+			},
+		},
+		{
+			"Running a function failing in some workspaces",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("List").Return(workspaces, nil)
+				w.Mock.On("RunFunction", "api", "", []string{"test"}, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				w.Mock.On("RunFunction", "api-admin", "", []string{"test"}, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("the function `test` does not exist"))
+				return w, []string{"--tag", "backend", "-j", "1", "test"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the function failed in 1 workspace(s) out of 2")
+				assert.Contains(t, outBuf.String(), "* api : success\n* api-admin : the function `test` does not exist\n")
+			},
+		},
+		{
+			"No workspaces match the selection",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("List").Return(workspaces, nil)
+				return w, []string{"--tag", "whatever", "test"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "no workspaces match the selection")
+			},
+		},
+		{
+			"An invalid pattern is provided",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("List").Return(workspaces, nil)
+				return w, []string{"--match", "[", "test"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, `"[" is not a valid pattern`)
+			},
+		},
+		{
+			"An error occurred when listing workspaces",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("List").Return([]workspace.Workspace{}, errors.New("an error occurred"))
+				return w, []string{"--all", "test"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "an error occurred")
+			},
+		},
+		{
+			"An invalid concurrency is provided",
+			func(t *testing.T) (workspaceManager, []string) {
+				return newMockWorkspaceManager(t), []string{"--all", "-j", "0", "test"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the concurrency must be greater than 0")
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.Setenv("EDITOR", "emacs")
+			os.Setenv("SHELL", "/bin/sh")
+			env = ""
+			errBuf := &bytes.Buffer{}
+			outBuf := &bytes.Buffer{}
+			w, args := s.setup(t)
+			cmd := newRunCmd(w, newMockCompletionManager(t))
+			cmd.SetArgs(args)
+			cmd.SetErr(errBuf)
+			cmd.SetOut(outBuf)
+			s.test(t, outBuf, errBuf, cmd.Execute())
+		})
+	}
+}
+
+func TestNewRunCmdInParallel(t *testing.T) {
+	type scenario struct {
+		name  string
+		setup func(*testing.T) (workspaceManager, []string)
+		test  func(*testing.T, *bytes.Buffer, *bytes.Buffer, error)
+	}
+	run := func(fn func(*workspace.RunOptions) error) func(string, string, []string, ...func(*workspace.RunOptions)) error {
+		return func(name string, env string, functionAndArgs []string, options ...func(*workspace.RunOptions)) error {
+			runOptions := &workspace.RunOptions{}
+			for _, o := range options {
+				o(runOptions)
+			}
+			return fn(runOptions)
+		}
+	}
+	scenarios := []scenario{
+		{
+			"Running several functions at the same time",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("RunDependencies", "api", "prod", []string{"lint", "typecheck"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				w.Mock.On("RunFunction", "api", "prod", []string{"lint"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(run(func(r *workspace.RunOptions) error {
+					assert.True(t, r.NoDeps)
+					_, err := r.Stdout.Write([]byte("lint output\n"))
+					return err
+				}))
+				w.Mock.On("RunFunction", "api", "prod", []string{"typecheck"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(run(func(r *workspace.RunOptions) error {
+					_, err := r.Stderr.Write([]byte("typecheck error"))
+					return err
+				}))
+				return w, []string{"--parallel", "-e", "prod", "api", "lint", "typecheck"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Contains(t, outBuf.String(), "[lint]      lint output\n")
+				assert.Equal(t, "[typecheck] typecheck error\n", errBuf.String())
+				assert.Contains(t, outBuf.String(), `Summary
+
+---
+* lint : success
+* typecheck : success
+`)
+			},
+		},
+		{
+			"A function fails",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("RunDependencies", "api", "", []string{"lint", "test"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				w.Mock.On("RunFunction", "api", "", []string{"lint"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("the function `lint` does not exist"))
+				w.Mock.On("RunFunction", "api", "", []string{"test"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				return w, []string{"--parallel", "api", "lint", "test"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the function `lint` does not exist")
+				assert.Contains(t, outBuf.String(), "* lint : the function `lint` does not exist\n* test : success\n")
+			},
+		},
+		{
+			"The other functions are stopped when a function fails",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				started := make(chan struct{})
+				w.Mock.On("RunDependencies", "api", "", []string{"lint", "test"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				w.Mock.On("RunFunction", "api", "", []string{"lint"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(run(func(r *workspace.RunOptions) error {
+					<-started
+					return errors.New("an error occurred")
+				}))
+				w.Mock.On("RunFunction", "api", "", []string{"test"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(run(func(r *workspace.RunOptions) error {
+					close(started)
+					<-r.Context.Done()
+					return fmt.Errorf("%w: signal: terminated", r.Context.Err())
+				}))
+				return w, []string{"--parallel", "--fail-fast", "api", "lint", "test"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "an error occurred")
+				assert.Contains(t, outBuf.String(), "* lint : an error occurred\n* test : canceled\n")
+			},
+		},
+		{
+			"Several functions fail on their own",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("RunDependencies", "api", "", []string{"lint", "test"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				w.Mock.On("RunFunction", "api", "", []string{"lint"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("lint failed"))
+				w.Mock.On("RunFunction", "api", "", []string{"test"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("test failed"))
+				return w, []string{"--parallel", "--fail-fast", "api", "lint", "test"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.Error(t, err)
+				assert.Contains(t, outBuf.String(), "* lint : lint failed\n* test : test failed\n")
+			},
+		},
+		{
+			"A dependency fails",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("RunDependencies", "api", "", []string{"lint", "test"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("the dependency `install` failed: exit status 1"))
+				return w, []string{"--parallel", "api", "lint", "test"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the dependency `install` failed: exit status 1")
+				assert.NotContains(t, outBuf.String(), "Summary")
+			},
+		},
+		{
+			"Notifying the end of the functions",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("RunDependencies", "api", "", []string{"lint", "test"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				w.Mock.On("RunFunction", "api", "", []string{"lint"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				w.Mock.On("RunFunction", "api", "", []string{"test"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				return w, []string{"--parallel", "--notify", "api", "lint", "test"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "\a", errBuf.String())
+			},
+		},
+		{
+			"The fail fast flag is provided without the parallel flag",
+			func(t *testing.T) (workspaceManager, []string) {
+				return newMockWorkspaceManager(t), []string{"--fail-fast", "api", "test"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the --fail-fast flag can only be used with the --parallel flag")
+			},
+		},
+		{
+			"The parallel flag is provided with the watch flag",
+			func(t *testing.T) (workspaceManager, []string) {
+				return newMockWorkspaceManager(t), []string{"--parallel", "--watch", "api", "lint", "test"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the --parallel flag can't be used with the --dry-run, --explain, --watch, --all, --match and --tag flags")
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.Setenv("EDITOR", "emacs")
+			os.Setenv("SHELL", "/bin/sh")
+			env = ""
+			errBuf := &bytes.Buffer{}
+			outBuf := &bytes.Buffer{}
+			w, args := s.setup(t)
+			cmd := newRunCmd(w, newMockCompletionManager(t))
+			cmd.SetArgs(args)
+			cmd.SetErr(errBuf)
+			cmd.SetOut(outBuf)
+			s.test(t, outBuf, errBuf, cmd.Execute())
+		})
+	}
+}
+
+func TestNewRunCmdDetached(t *testing.T) {
+	type scenario struct {
+		name  string
+		setup func(*testing.T) (workspaceManager, []string)
+		test  func(*testing.T, *bytes.Buffer, *bytes.Buffer, error)
+	}
+	scenarios := []scenario{
+		{
+			"Running a function in the background",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("StartJob", "api", "prod", []string{"serve", "8080"}).Return(workspace.Job{ID: 2}, nil)
+				return w, []string{"--detach", "-e", "prod", "api", "serve", "8080"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "The function runs in the background as the job 2, run \"wo logs 2\" to read its output and \"wo stop 2\" to stop it\n", outBuf.String())
+			},
+		},
+		{
+			"Running a function in the background without its dependencies",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("StartJob", "api", "", []string{"serve"}, mock.Anything).Return(workspace.Job{ID: 1}, nil)
+				return w, []string{"-d", "--no-deps", "api", "serve"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"An error occurred when starting the function",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("StartJob", "api", "", []string{"serve"}).Return(workspace.Job{}, errors.New("the function `serve` does not exist"))
+				return w, []string{"--detach", "api", "serve"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the function `serve` does not exist")
+			},
+		},
+		{
+			"The detach flag is provided with the timeout flag",
+			func(t *testing.T) (workspaceManager, []string) {
+				return newMockWorkspaceManager(t), []string{"--detach", "--timeout", "1m", "api", "serve"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the --detach flag can't be used with the --dry-run, --explain, --watch, --parallel, --timeout, --retry, --all, --match and --tag flags")
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.Setenv("EDITOR", "emacs")
+			os.Setenv("SHELL", "/bin/sh")
+			env = ""
+			errBuf := &bytes.Buffer{}
+			outBuf := &bytes.Buffer{}
+			w, args := s.setup(t)
+			cmd := newRunCmd(w, newMockCompletionManager(t))
+			cmd.SetArgs(args)
+			cmd.SetErr(errBuf)
+			cmd.SetOut(outBuf)
+			s.test(t, outBuf, errBuf, cmd.Execute())
+		})
+	}
+}
+
+func TestNewRunCmdCapturingOutput(t *testing.T) {
+	type scenario struct {
+		name  string
+		setup func(*testing.T, string) (workspaceManager, []string)
+		test  func(*testing.T, string, *bytes.Buffer, *bytes.Buffer, error)
+	}
+	run := func(stdout string, stderr string, err error) func(string, string, []string, ...func(*workspace.RunOptions)) error {
+		return func(name string, env string, functionAndArgs []string, options ...func(*workspace.RunOptions)) error {
+			runOptions := &workspace.RunOptions{}
+			for _, o := range options {
+				o(runOptions)
+			}
+			_, _ = runOptions.Stdout.Write([]byte(stdout))
+			_, _ = runOptions.Stderr.Write([]byte(stderr))
+			return err
+		}
+	}
+	scenarios := []scenario{
+		{
+			"Writing the output in a file",
+			func(t *testing.T, file string) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("RunFunction", "api", "", []string{"test"}, mock.Anything, mock.Anything, mock.Anything).Return(run("ok\n", "warning\n", nil))
+				return w, []string{"--output-file", file, "api", "test"}
+			},
+			func(t *testing.T, file string, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				content, err := os.ReadFile(file)
+				assert.NoError(t, err)
+				assert.Equal(t, "ok\nwarning\n", string(content))
+				assert.Empty(t, outBuf.String())
+				assert.Empty(t, errBuf.String())
+			},
+		},
+		{
+			"Writing the output in a file and in the terminal",
+			func(t *testing.T, file string) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("RunFunction", "api", "", []string{"test"}, mock.Anything, mock.Anything, mock.Anything).Return(run("ok\n", "warning\n", nil))
+				return w, []string{"--output-file", file, "--tee", "api", "test"}
+			},
+			func(t *testing.T, file string, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				content, err := os.ReadFile(file)
+				assert.NoError(t, err)
+				assert.Equal(t, "ok\nwarning\n", string(content))
+				assert.Equal(t, "ok\n", outBuf.String())
+				assert.Equal(t, "warning\n", errBuf.String())
+			},
+		},
+		{
+			"Printing the result as json",
+			func(t *testing.T, file string) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetRunPlan", "api", "", []string{"test", "./..."}).Return(workspace.RunPlan{Workspace: "api", Env: "prod", Function: "test", FunctionArgs: []string{"./..."}}, nil)
+				w.Mock.On("RunFunction", "api", "", []string{"test", "./..."}, mock.Anything, mock.Anything, mock.Anything).Return(run("ok\n", "warning\n", nil))
+				return w, []string{"--json", "--output-file", file, "api", "test", "./..."}
+			},
+			func(t *testing.T, file string, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				output := runOutput{}
+				assert.NoError(t, json.Unmarshal(outBuf.Bytes(), &output))
+				assert.GreaterOrEqual(t, output.Duration, 0.0)
+				output.Duration = 0
+				assert.Equal(t, runOutput{
+					Workspace: "api",
+					Env:       "prod",
+					Function:  "test",
+					Args:      []string{"./..."},
+					Stdout:    "ok\n",
+					Stderr:    "warning\n",
+				}, output)
+				content, err := os.ReadFile(file)
+				assert.NoError(t, err)
+				assert.Equal(t, "ok\nwarning\n", string(content))
+			},
+		},
+		{
+			"The function can't be run",
+			func(t *testing.T, file string) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetRunPlan", "api", "", []string{"test"}).Return(workspace.RunPlan{Workspace: "api", Env: "default", Function: "test"}, nil)
+				w.Mock.On("RunFunction", "api", "", []string{"test"}, mock.Anything, mock.Anything, mock.Anything).Return(run("", "", errors.New("an error occurred")))
+				return w, []string{"--json", "api", "test"}
+			},
+			func(t *testing.T, file string, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "an error occurred")
+				output := runOutput{}
+				assert.NoError(t, json.Unmarshal(outBuf.Bytes(), &output))
+				assert.Equal(t, -1, output.ExitCode)
+				assert.Equal(t, "an error occurred", output.Error)
+				assert.Empty(t, errBuf.String())
+			},
+		},
+		{
+			"The function does not exist",
+			func(t *testing.T, file string) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetRunPlan", "api", "", []string{"test"}).Return(workspace.RunPlan{}, errors.New("the function `test` does not exist"))
+				return w, []string{"--json", "api", "test"}
+			},
+			func(t *testing.T, file string, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the function `test` does not exist")
+				assert.NotContains(t, outBuf.String(), "exit_code")
+			},
+		},
+		{
+			"The tee flag is provided without the output file flag",
+			func(t *testing.T, file string) (workspaceManager, []string) {
+				return newMockWorkspaceManager(t), []string{"--tee", "api", "test"}
+			},
+			func(t *testing.T, file string, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the --tee flag can only be used with the --output-file flag and without the --json flag")
+			},
+		},
+		{
+			"The json flag is provided with the parallel flag",
+			func(t *testing.T, file string) (workspaceManager, []string) {
+				return newMockWorkspaceManager(t), []string{"--json", "--parallel", "api", "lint", "test"}
+			},
+			func(t *testing.T, file string, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the --output-file and --json flags can't be used with the --dry-run, --explain, --watch, --parallel, --detach, --all, --match and --tag flags")
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.Setenv("EDITOR", "emacs")
+			os.Setenv("SHELL", "/bin/sh")
+			env = ""
+			file := filepath.Join(t.TempDir(), "output.log")
+			errBuf := &bytes.Buffer{}
+			outBuf := &bytes.Buffer{}
+			w, args := s.setup(t, file)
+			cmd := newRunCmd(w, newMockCompletionManager(t))
+			cmd.SetArgs(args)
+			cmd.SetErr(errBuf)
+			cmd.SetOut(outBuf)
+			s.test(t, file, outBuf, errBuf, cmd.Execute())
+		})
+	}
+}
+
+func TestNewRunCmdWithPlan(t *testing.T) {
+	type scenario struct {
+		name  string
+		setup func(*testing.T) (workspaceManager, []string)
+		test  func(*testing.T, *bytes.Buffer, error)
+	}
+	plan := workspace.RunPlan{
+		Workspace: "api",
+		Env:       "prod",
+		Function:  "deploy",
+		Shell:     "bash",
+		Path:      "/tmp/api",
+		Variables: []workspace.EnvVariable{{Name: "WO_NAME", Value: "api"}, {Name: "WO_ENV", Value: "prod"}},
+		Files:     []string{"/tmp/envs/prod.bash", "/tmp/functions/functions.bash"},
+		Args:      []string{"-c", "export WO_NAME=api && export WO_ENV=prod && source /tmp/envs/prod.bash && source /tmp/functions/functions.bash && deploy v1"},
+	}
+	scenarios := []scenario{
+		{
+			"Printing the script",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetRunPlan", "api", "prod", []string{"deploy", "v1"}).Return(plan, nil)
+				return w, []string{"--dry-run", "-e", "prod", "api", "deploy", "v1"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "cd /tmp/api\nbash -c 'export WO_NAME=api && export WO_ENV=prod && source /tmp/envs/prod.bash && source /tmp/functions/functions.bash && deploy v1'\n", outBuf.String())
+			},
+		},
+		{
+			"Explaining the run",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetRunPlan", "api", "", []string{"deploy", "v1"}).Return(plan, nil)
+				return w, []string{"--explain", "api", "deploy", "v1"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, `Run
+
+* workspace : api
+* env : prod
+* function : deploy
+* shell : bash
+* path : /tmp/api
+
+---
+Exported variables
+
+* WO_NAME : api
+* WO_ENV : prod
+
+---
+Sourced files
+
+* 1 : /tmp/envs/prod.bash
+* 2 : /tmp/functions/functions.bash
+
+---
+`, outBuf.String())
+			},
+		},
+		{
+			"Printing the script with the dependencies",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				p := plan
+				p.Dependencies = []workspace.RunPlan{{Shell: "bash", Path: "/tmp/api", Function: "build", Args: []string{"-c", "build"}}}
+				w.Mock.On("GetRunPlan", "api", "", []string{"deploy", "v1"}).Return(p, nil)
+				return w, []string{"--dry-run", "api", "deploy", "v1"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "cd /tmp/api\nbash -c build\ncd /tmp/api\nbash -c 'export WO_NAME=api && export WO_ENV=prod && source /tmp/envs/prod.bash && source /tmp/functions/functions.bash && deploy v1'\n", outBuf.String())
+			},
+		},
+		{
+			"Explaining the run with the dependencies",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				p := plan
+				p.Dependencies = []workspace.RunPlan{{Function: "lint"}, {Function: "build"}}
+				w.Mock.On("GetRunPlan", "api", "", []string{"deploy", "v1"}).Return(p, nil)
+				return w, []string{"--explain", "api", "deploy", "v1"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Contains(t, outBuf.String(), "* path : /tmp/api\n* dependencies : lint, build\n")
+			},
+		},
+		{
+			"Printing the script without the dependencies",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetRunPlan", "api", "", []string{"deploy", "v1"}, mock.Anything).Return(plan, nil)
+				return w, []string{"--dry-run", "--no-deps", "api", "deploy", "v1"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "cd /tmp/api\nbash -c 'export WO_NAME=api && export WO_ENV=prod && source /tmp/envs/prod.bash && source /tmp/functions/functions.bash && deploy v1'\n", outBuf.String())
+			},
+		},
+		{
+			"An error occurred when resolving the run",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetRunPlan", "api", "", []string{"build"}).Return(workspace.RunPlan{}, errors.New("the function `build` does not exist"))
+				return w, []string{"--dry-run", "api", "build"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the function `build` does not exist")
+			},
+		},
+		{
+			"Explaining the run in several workspaces",
+			func(t *testing.T) (workspaceManager, []string) {
+				return newMockWorkspaceManager(t), []string{"--explain", "--all", "deploy"}
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the --dry-run and --explain flags can't be used with the --all, --match and --tag flags")
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.Setenv("EDITOR", "emacs")
+			os.Setenv("SHELL", "/bin/sh")
+			outBuf := &bytes.Buffer{}
+			w, args := s.setup(t)
+			cmd := newRunCmd(w, newMockCompletionManager(t))
+			cmd.SetArgs(args)
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetOut(outBuf)
+			s.test(t, outBuf, cmd.Execute())
+		})
+	}
+}
+
+func TestNewRunCmdWatching(t *testing.T) {
+	type scenario struct {
+		name  string
+		args  []string
+		setup func(*testing.T, string, context.CancelFunc) workspaceManager
+		test  func(*testing.T, *bytes.Buffer, error)
+	}
+	scenarios := []scenario{
+		{
+			"Running again the function when a file changes",
+			[]string{"--watch", "--glob", "*.go", "--debounce", "10ms", "api", "test"},
+			func(t *testing.T, dir string, cancel context.CancelFunc) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("Get", "api").Return(workspace.Workspace{Name: "api", Config: map[string]string{"path": dir}}, nil)
+				w.Mock.On("RunFunction", "api", "", []string{"test"}, mock.Anything, mock.Anything, mock.Anything).Return(func(name string, env string, functionAndArgs []string, options ...func(*workspace.RunOptions)) error {
+					assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte(""), 0o666))
+					assert.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte(""), 0o666))
+					return exec.Command("/bin/sh", "-c", "exit 2").Run()
+				}).Once()
+				w.Mock.On("RunFunction", "api", "", []string{"test"}, mock.Anything, mock.Anything, mock.Anything).Return(func(name string, env string, functionAndArgs []string, options ...func(*workspace.RunOptions)) error {
+					runOptions := &workspace.RunOptions{}
+					for _, o := range options {
+						o(runOptions)
+					}
+					cancel()
+					<-runOptions.Context.Done()
+					return nil
+				}).Once()
+				return w
+			},
+			func(t *testing.T, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "The function failed with exit code 2, waiting for changes\nChanges detected in main.go, running the function again\n", errBuf.String())
+			},
+		},
+		{
+			"Stopping when the function can't be run",
+			[]string{"--watch", "api", "test"},
+			func(t *testing.T, dir string, cancel context.CancelFunc) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("Get", "api").Return(workspace.Workspace{Name: "api", Config: map[string]string{"path": dir}}, nil)
+				w.Mock.On("RunFunction", "api", "", []string{"test"}, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("the function `test` does not exist"))
+				return w
+			},
+			func(t *testing.T, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the function `test` does not exist")
+			},
+		},
+		{
+			"Using a glob without watching",
+			[]string{"--glob", "*.go", "api", "test"},
+			func(t *testing.T, dir string, cancel context.CancelFunc) workspaceManager {
+				return newMockWorkspaceManager(t)
+			},
+			func(t *testing.T, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the --glob flag can only be used with the --watch flag")
+			},
+		},
+		{
+			"Watching several workspaces",
+			[]string{"--watch", "--all", "test"},
+			func(t *testing.T, dir string, cancel context.CancelFunc) workspaceManager {
+				return newMockWorkspaceManager(t)
+			},
+			func(t *testing.T, errBuf *bytes.Buffer, err error) {
+				assert.EqualError(t, err, "the --watch flag can't be used with the --dry-run, --explain, --all, --match and --tag flags")
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.Setenv("EDITOR", "emacs")
+			os.Setenv("SHELL", "/bin/sh")
+			env = ""
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			errBuf := &bytes.Buffer{}
+			w := s.setup(t, t.TempDir(), cancel)
+			cmd := newRunCmd(w, newMockCompletionManager(t))
+			cmd.SetArgs(s.args)
+			cmd.SetErr(errBuf)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SilenceErrors = true
+			cmd.SilenceUsage = true
+			s.test(t, errBuf, cmd.ExecuteContext(ctx))
+		})
+	}
+}
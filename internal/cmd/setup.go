@@ -1,32 +1,175 @@
 package cmd
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/antham/wo/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
+// nuCompletion lists the candidates of a wo command line, it's attached to
+// the wo command defined by the nushell dispatcher
+const nuCompletion = `def "nu-complete wo" [context: string] {
+    ^wo __complete ...($context | split row " " | skip 1)
+    | lines
+    | where {|line| not ($line | str starts-with ":") }
+    | each {|line|
+        let parts = ($line | split row "\t")
+        {value: ($parts | first), description: (if ($parts | length) > 1 { $parts.1 } else { "" })}
+    }
+}
+`
+
+// posixCompletion provides a wo_complete function to POSIX shells like ash or
+// dash which have no programmable completion, it lists the candidates of a
+// partial command line e.g. wo_complete run api "" lists the api functions
+const posixCompletion = `wo_complete() {
+    if [ "$#" -eq 0 ]; then
+        set -- ""
+    fi
+    wo __complete "$@" 2>/dev/null | while IFS= read -r wo_line; do
+        case "$wo_line" in
+        :*) ;;
+        *) printf '%s\n' "$wo_line" ;;
+        esac
+    done
+}
+`
+
+// posixDispatcher wraps wo in a function to be able to change the current
+// directory with wo cd, the path is printed instead when the output is not a
+// terminal, the aliases are refreshed after each command when the workspaces
+// changed. Only builtins are used to know if the aliases file is outdated so
+// wo is run only when the workspaces changed, the first line of the file
+// tells if the shell already sourced it, the prefix given at setup is kept
+const posixDispatcher = `__wo_workspaces_dir={{workspaces_dir}}
+__wo_aliases_file={{aliases_file}}
+wo() {
+    if [ "${1:-}" = "cd" ]; then
+        shift
+        __wo_path="$(command wo cd "$@")" || return
+        if [ -t 1 ]; then
+            cd "$__wo_path"
+        else
+            printf '%s\n' "$__wo_path"
+        fi
+        return
+    fi
+    command wo "$@"
+    __wo_status=$?
+    case "${1:-}" in
+    __*) ;;
+    *) __wo_refresh_aliases ;;
+    esac
+    return $__wo_status
+}
+__wo_refresh_aliases() {
+    if [ ! -f "$__wo_aliases_file" ] || [ "$__wo_workspaces_dir" -nt "$__wo_aliases_file" ]; then
+        command wo setup {{shell}} --refresh{{prefix}} || return
+    fi
+    IFS= read -r __wo_header < "$__wo_aliases_file" || return
+    if [ "$__wo_header" != "# ${__wo_aliases_mtime:-}" ]; then
+        . "$__wo_aliases_file"
+    fi
+}
+`
+
+const bashPromptHook = `if [[ ";${PROMPT_COMMAND:-};" != *";__wo_refresh_aliases;"* ]]; then
+    PROMPT_COMMAND="__wo_refresh_aliases${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi
+`
+
+const zshPromptHook = `autoload -Uz add-zsh-hook
+add-zsh-hook precmd __wo_refresh_aliases
+`
+
+// fishDispatcher compares the modification times with the path builtin as
+// the test builtin of fish can't compare files
+const fishDispatcher = `set -g __wo_workspaces_dir {{workspaces_dir}}
+set -g __wo_aliases_file {{aliases_file}}
+function wo
+    if test (count $argv) -gt 0; and test "$argv[1]" = cd
+        set -l wo_path (command wo cd $argv[2..-1]); or return
+        if isatty stdout
+            cd $wo_path
+        else
+            echo $wo_path
+        end
+        return
+    end
+    command wo $argv
+    set -l wo_status $status
+    if not string match -q -- '__*' "$argv[1]"
+        __wo_refresh_aliases
+    end
+    return $wo_status
+end
+function __wo_refresh_aliases --on-event fish_prompt
+    if not test -f $__wo_aliases_file; or begin; test -e $__wo_workspaces_dir; and test (path mtime $__wo_workspaces_dir) -ge (path mtime $__wo_aliases_file); end
+        command wo setup fish --refresh{{prefix}}; or return
+    end
+    read -l wo_header < $__wo_aliases_file; or return
+    if test "$wo_header" != "# $__wo_aliases_mtime"
+        source $__wo_aliases_file
+    end
+end
+`
+
+// nuDispatcher can't refresh the aliases as nushell parses them before running
+// anything, wo cd resolves the workspace path at runtime though
+const nuDispatcher = `def --env --wrapped wo [...args: string@"nu-complete wo"] {
+    if ($args | get 0? | default "") == "cd" {
+        cd (^wo cd ...($args | skip 1) | str trim)
+    } else {
+        ^wo ...$args
+    }
+}
+`
+
 func newSetupCmd(workspaceManager workspaceManager) *cobra.Command {
 	var prefix string
 	var theme string
+	var refresh bool
 	cmd := &cobra.Command{
 		Use:       "setup shell",
 		Short:     "Command to setup wo in the shell",
 		Args:      cobra.ExactArgs(1),
-		ValidArgs: []string{"bash", "fish", "zsh", "sh"},
+		ValidArgs: []string{"bash", "fish", "zsh", "sh", "ksh", "dash", "nu"},
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if !slices.Contains(cmd.ValidArgs, args[0]) {
 				return fmt.Errorf("the first argument must one of among: %v", cmd.ValidArgs)
 			}
+			if cmd.Flags().Changed("refresh") && args[0] == "nu" {
+				return fmt.Errorf(`the aliases can't be refreshed with "%s"`, args[0])
+			}
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			modTime, err := workspaceManager.GetWorkspacesModTime()
+			if err != nil {
+				return err
+			}
+			mtime := "0"
+			if !modTime.IsZero() {
+				mtime = strconv.FormatInt(modTime.UnixNano(), 10)
+			}
+			aliasesFile := filepath.Join(workspaceManager.GetCacheDir(), "aliases."+args[0])
+			if refresh {
+				return writeAliases(cmd, workspaceManager, aliasesFile, args[0], prefix, mtime)
+			}
+
 			// We need this to be able to have the completion working
 			c := &cobra.Command{
 				Use: "wo",
 			}
-			var err error
 			switch args[0] {
 			case "bash":
 				err = c.GenBashCompletionV2(cmd.OutOrStdout(), true)
@@ -34,26 +177,133 @@ func newSetupCmd(workspaceManager workspaceManager) *cobra.Command {
 				err = c.GenFishCompletion(cmd.OutOrStdout(), true)
 			case "zsh":
 				err = c.GenZshCompletion(cmd.OutOrStdout())
+			case "nu":
+				cmd.Print(nuCompletion)
+			case "sh", "dash", "ksh":
+				_, err = io.WriteString(cmd.OutOrStdout(), posixCompletion)
 			}
 			if err != nil {
 				return err
 			}
+			workspacesDir := filepath.Join(workspaceManager.GetConfigDir(), "workspaces")
+			// The aliases keep the prefix of the global config when none is
+			// given, it may change after the setup
+			dispatcherPrefix := ""
+			if cmd.Flags().Changed("prefix") {
+				dispatcherPrefix = prefix
+			}
+			_, err = io.WriteString(cmd.OutOrStdout(), buildDispatcher(args[0], workspacesDir, aliasesFile, dispatcherPrefix))
+			if err != nil {
+				return err
+			}
 
-			aliases, err := workspaceManager.BuildAliases(prefix)
+			err = printAliases(cmd, cmd.OutOrStdout(), workspaceManager, args[0], prefix, mtime)
 			if err != nil {
 				return err
 			}
-			for _, alias := range aliases {
-				cmd.Println(alias)
+			// The theme is exported only when it is explicitly provided,
+			// otherwise it is read from the global config when wo runs
+			if !cmd.Flags().Changed("theme") {
+				return nil
 			}
 			if !slices.Contains([]string{"dark", "light"}, theme) {
 				return fmt.Errorf(`"%s" theme is not supported, must be either "light" or "dark"`, theme)
 			}
-			cmd.Println(workspaceManager.CreateEnvVariableStatement("WO_THEME", theme))
+			cmd.Println(workspaceManager.CreateEnvVariableStatement(args[0], "WO_THEME", theme))
 			return nil
 		},
 	}
-	cmd.Flags().StringVarP(&prefix, "prefix", "p", "c_", "Prefix name to use for the aliases")
-	cmd.Flags().StringVarP(&theme, "theme", "t", "light", "Theme to use")
+	cmd.Flags().StringVarP(&prefix, "prefix", "p", "c_", "Prefix name to use for the aliases, defaults to the global config")
+	cmd.Flags().StringVarP(&theme, "theme", "t", "light", "Theme to use, defaults to the global config")
+	cmd.Flags().BoolVar(&refresh, "refresh", false, "Write the aliases in the aliases file sourced by the shell instead of printing the setup")
+	_ = cmd.Flags().MarkHidden("refresh")
 	return cmd
 }
+
+// buildDispatcher returns the dispatcher of the shell, the aliases are
+// refreshed with the prefix when it's not empty
+func buildDispatcher(shell string, workspacesDir string, aliasesFile string, prefix string) string {
+	dispatcher := posixDispatcher
+	switch shell {
+	case "fish":
+		dispatcher = fishDispatcher
+	case "nu":
+		return nuDispatcher
+	}
+	prefixFlag := ""
+	if prefix != "" {
+		prefixFlag = " -p " + quoteArg(shell, prefix)
+	}
+	dispatcher = strings.NewReplacer(
+		"{{shell}}", shell,
+		"{{workspaces_dir}}", quoteArg(shell, workspacesDir),
+		"{{aliases_file}}", quoteArg(shell, aliasesFile),
+		"{{prefix}}", prefixFlag,
+	).Replace(dispatcher)
+	switch shell {
+	case "bash":
+		dispatcher += bashPromptHook
+	case "zsh":
+		dispatcher += zshPromptHook
+	}
+	return dispatcher
+}
+
+// quoteArg quotes an argument in the syntax of the shell
+func quoteArg(shell string, arg string) string {
+	if shell == "fish" {
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(arg) + "'"
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// writeAliases writes the aliases in the file sourced by the shells, the
+// file starts with the modification time of the workspaces
+func writeAliases(cmd *cobra.Command, workspaceManager workspaceManager, file string, shell string, prefix string, mtime string) error {
+	content := &bytes.Buffer{}
+	fmt.Fprintf(content, "# %s\n", mtime)
+	err := printAliases(cmd, content, workspaceManager, shell, prefix, mtime)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(file), 0o777)
+	if err != nil {
+		return err
+	}
+	// The file is replaced at once so a shell never sources it half written
+	f, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file))
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(content.Bytes())
+	err = errors.Join(err, f.Close())
+	if err != nil {
+		return errors.Join(err, os.Remove(f.Name()))
+	}
+	return os.Rename(f.Name(), file)
+}
+
+func printAliases(cmd *cobra.Command, w io.Writer, workspaceManager workspaceManager, shell string, prefix string, mtime string) error {
+	if !cmd.Flags().Changed("prefix") {
+		var err error
+		prefix, err = workspaceManager.GetGlobalConfig(workspace.GlobalAliasPrefix)
+		if err != nil {
+			return err
+		}
+	}
+	aliases, err := workspaceManager.BuildAliases(shell, prefix)
+	if err != nil {
+		return err
+	}
+	for _, alias := range aliases {
+		fmt.Fprintln(w, alias)
+	}
+	switch shell {
+	case "fish":
+		fmt.Fprintf(w, "set -g __wo_aliases_mtime %s\n", mtime)
+	case "nu":
+	default:
+		fmt.Fprintf(w, "__wo_aliases_mtime=%s\n", mtime)
+	}
+	return nil
+}
@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"errors"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewSetupCmd(t *testing.T) {
+	cacheDir := t.TempDir()
 	type scenario struct {
 		name  string
 		args  []string
@@ -32,7 +35,11 @@ func TestNewSetupCmd(t *testing.T) {
 			[]string{"fish"},
 			func(t *testing.T) workspaceManager {
 				w := newMockWorkspaceManager(t)
-				w.Mock.On("BuildAliases", "c_").Return([]string{}, errors.New("an error occurred"))
+				w.Mock.On("GetWorkspacesModTime").Return(time.Unix(0, 10), nil)
+				w.Mock.On("GetCacheDir").Return(cacheDir)
+				w.Mock.On("GetConfigDir").Return("/config")
+				w.Mock.On("GetGlobalConfig", "alias-prefix").Return("c_", nil)
+				w.Mock.On("BuildAliases", "fish", "c_").Return([]string{}, errors.New("an error occurred"))
 				return w
 			},
 			func(t *testing.T, stdout *bytes.Buffer, stderr *bytes.Buffer, err error) {
@@ -44,7 +51,11 @@ func TestNewSetupCmd(t *testing.T) {
 			[]string{"fish"},
 			func(t *testing.T) workspaceManager {
 				w := newMockWorkspaceManager(t)
-				w.Mock.On("BuildAliases", "c_").
+				w.Mock.On("GetWorkspacesModTime").Return(time.Unix(0, 10), nil)
+				w.Mock.On("GetCacheDir").Return(cacheDir)
+				w.Mock.On("GetConfigDir").Return("/config")
+				w.Mock.On("GetGlobalConfig", "alias-prefix").Return("c_", nil)
+				w.Mock.On("BuildAliases", "fish", "c_").
 					Return(
 						[]string{
 							`alias c_front="cd /tmp/front"`,
@@ -52,7 +63,6 @@ func TestNewSetupCmd(t *testing.T) {
 						},
 						nil,
 					)
-				w.Mock.On("CreateEnvVariableStatement", "WO_THEME", "light").Return("set -x -g WO_THEME light")
 				return w
 			},
 			func(t *testing.T, stdout *bytes.Buffer, stderr *bytes.Buffer, err error) {
@@ -73,7 +83,11 @@ alias c_test="cd /tmp/test"`,
 			[]string{"bash"},
 			func(t *testing.T) workspaceManager {
 				w := newMockWorkspaceManager(t)
-				w.Mock.On("BuildAliases", "c_").
+				w.Mock.On("GetWorkspacesModTime").Return(time.Unix(0, 10), nil)
+				w.Mock.On("GetCacheDir").Return(cacheDir)
+				w.Mock.On("GetConfigDir").Return("/config")
+				w.Mock.On("GetGlobalConfig", "alias-prefix").Return("c_", nil)
+				w.Mock.On("BuildAliases", "bash", "c_").
 					Return(
 						[]string{
 							`alias c_front="cd /tmp/front"`,
@@ -81,7 +95,6 @@ alias c_test="cd /tmp/test"`,
 						},
 						nil,
 					)
-				w.Mock.On("CreateEnvVariableStatement", "WO_THEME", "light").Return("export WO_THEME=light")
 				return w
 			},
 			func(t *testing.T, stdout *bytes.Buffer, stderr *bytes.Buffer, err error) {
@@ -102,7 +115,11 @@ alias c_test="cd /tmp/test"`,
 			[]string{"zsh"},
 			func(t *testing.T) workspaceManager {
 				w := newMockWorkspaceManager(t)
-				w.Mock.On("BuildAliases", "c_").
+				w.Mock.On("GetWorkspacesModTime").Return(time.Unix(0, 10), nil)
+				w.Mock.On("GetCacheDir").Return(cacheDir)
+				w.Mock.On("GetConfigDir").Return("/config")
+				w.Mock.On("GetGlobalConfig", "alias-prefix").Return("c_", nil)
+				w.Mock.On("BuildAliases", "zsh", "c_").
 					Return(
 						[]string{
 							`alias c_front="cd /tmp/front"`,
@@ -110,7 +127,6 @@ alias c_test="cd /tmp/test"`,
 						},
 						nil,
 					)
-				w.Mock.On("CreateEnvVariableStatement", "WO_THEME", "light").Return("export WO_THEME=light")
 				return w
 			},
 			func(t *testing.T, stdout *bytes.Buffer, stderr *bytes.Buffer, err error) {
@@ -127,27 +143,30 @@ alias c_test="cd /tmp/test"`,
 			},
 		},
 		{
-			"We do not get the completion for sh",
+			"We get the lightweight completion for sh and aliases",
 			[]string{"sh"},
 			func(t *testing.T) workspaceManager {
 				w := newMockWorkspaceManager(t)
-				w.Mock.On("BuildAliases", "c_").
+				w.Mock.On("GetWorkspacesModTime").Return(time.Unix(0, 10), nil)
+				w.Mock.On("GetCacheDir").Return(cacheDir)
+				w.Mock.On("GetConfigDir").Return("/config")
+				w.Mock.On("GetGlobalConfig", "alias-prefix").Return("c_", nil)
+				w.Mock.On("BuildAliases", "sh", "c_").
 					Return(
 						[]string{
-							`alias c_front="cd /tmp/front"`,
-							`alias c_test="cd /tmp/test"`,
+							`c_front() { cd /tmp/front; }`,
+							`c_test() { cd /tmp/test; }`,
 						},
 						nil,
 					)
-				w.Mock.On("CreateEnvVariableStatement", "WO_THEME", "light").Return("export WO_THEME=light")
 				return w
 			},
 			func(t *testing.T, stdout *bytes.Buffer, stderr *bytes.Buffer, err error) {
 				assert.NoError(t, err)
 				assert.Equal(t,
-					`alias c_front="cd /tmp/front"
-alias c_test="cd /tmp/test"
-export WO_THEME=light
+					posixCompletion+buildDispatcher("sh", "/config/workspaces", filepath.Join(cacheDir, "aliases.sh"), "")+`c_front() { cd /tmp/front; }
+c_test() { cd /tmp/test; }
+__wo_aliases_mtime=10
 `,
 					stdout.String(),
 				)
@@ -158,16 +177,19 @@ export WO_THEME=light
 			[]string{"fish", "-p", "test_"},
 			func(t *testing.T) workspaceManager {
 				w := newMockWorkspaceManager(t)
-				w.Mock.On("BuildAliases", "test_").
+				w.Mock.On("GetWorkspacesModTime").Return(time.Unix(0, 10), nil)
+				w.Mock.On("GetCacheDir").Return(cacheDir)
+				w.Mock.On("GetConfigDir").Return("/config")
+				w.Mock.On("BuildAliases", "fish", "test_").
 					Return(
 						[]string{},
 						nil,
 					)
-				w.Mock.On("CreateEnvVariableStatement", "WO_THEME", "light").Return("set -x -g WO_THEME light")
 				return w
 			},
 			func(t *testing.T, stdout *bytes.Buffer, stderr *bytes.Buffer, err error) {
 				assert.NoError(t, err)
+				assert.Contains(t, stdout.String(), "command wo setup fish --refresh -p 'test_'; or return\n")
 			},
 		},
 		{
@@ -175,7 +197,11 @@ export WO_THEME=light
 			[]string{"fish", "-t", "whatever"},
 			func(t *testing.T) workspaceManager {
 				w := newMockWorkspaceManager(t)
-				w.Mock.On("BuildAliases", "c_").
+				w.Mock.On("GetWorkspacesModTime").Return(time.Unix(0, 10), nil)
+				w.Mock.On("GetCacheDir").Return(cacheDir)
+				w.Mock.On("GetConfigDir").Return("/config")
+				w.Mock.On("GetGlobalConfig", "alias-prefix").Return("c_", nil)
+				w.Mock.On("BuildAliases", "fish", "c_").
 					Return(
 						[]string{},
 						nil,
@@ -191,16 +217,157 @@ export WO_THEME=light
 			[]string{"fish", "-t", "dark"},
 			func(t *testing.T) workspaceManager {
 				w := newMockWorkspaceManager(t)
-				w.Mock.On("BuildAliases", "c_").
+				w.Mock.On("GetWorkspacesModTime").Return(time.Unix(0, 10), nil)
+				w.Mock.On("GetCacheDir").Return(cacheDir)
+				w.Mock.On("GetConfigDir").Return("/config")
+				w.Mock.On("GetGlobalConfig", "alias-prefix").Return("c_", nil)
+				w.Mock.On("BuildAliases", "fish", "c_").
 					Return(
 						[]string{},
 						nil,
 					)
-				w.Mock.On("CreateEnvVariableStatement", "WO_THEME", "dark").Return("set -x -g WO_THEME dark")
+				w.Mock.On("CreateEnvVariableStatement", "fish", "WO_THEME", "dark").Return("set -x -g WO_THEME dark")
+				return w
+			},
+			func(t *testing.T, stdout *bytes.Buffer, stderr *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"Listing aliases with the prefix defined in the global config",
+			[]string{"sh"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetWorkspacesModTime").Return(time.Unix(0, 10), nil)
+				w.Mock.On("GetCacheDir").Return(cacheDir)
+				w.Mock.On("GetConfigDir").Return("/config")
+				w.Mock.On("GetGlobalConfig", "alias-prefix").Return("w_", nil)
+				w.Mock.On("BuildAliases", "sh", "w_").
+					Return(
+						[]string{
+							`w_front() { cd /tmp/front; }`,
+						},
+						nil,
+					)
+				return w
+			},
+			func(t *testing.T, stdout *bytes.Buffer, stderr *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, posixCompletion+buildDispatcher("sh", "/config/workspaces", filepath.Join(cacheDir, "aliases.sh"), "")+"w_front() { cd /tmp/front; }\n__wo_aliases_mtime=10\n", stdout.String())
+			},
+		},
+		{
+			"An error occurred when getting the prefix from the global config",
+			[]string{"sh"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetWorkspacesModTime").Return(time.Unix(0, 10), nil)
+				w.Mock.On("GetCacheDir").Return(cacheDir)
+				w.Mock.On("GetConfigDir").Return("/config")
+				w.Mock.On("GetGlobalConfig", "alias-prefix").Return("", errors.New("an error occurred"))
+				return w
+			},
+			func(t *testing.T, stdout *bytes.Buffer, stderr *bytes.Buffer, err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			"We get the lightweight completion for ksh and dash and aliases",
+			[]string{"ksh"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetWorkspacesModTime").Return(time.Unix(0, 10), nil)
+				w.Mock.On("GetCacheDir").Return(cacheDir)
+				w.Mock.On("GetConfigDir").Return("/config")
+				w.Mock.On("GetGlobalConfig", "alias-prefix").Return("c_", nil)
+				w.Mock.On("BuildAliases", "ksh", "c_").
+					Return(
+						[]string{
+							`c_front() { cd /tmp/front; }`,
+						},
+						nil,
+					)
+				return w
+			},
+			func(t *testing.T, stdout *bytes.Buffer, stderr *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, posixCompletion+buildDispatcher("ksh", "/config/workspaces", filepath.Join(cacheDir, "aliases.ksh"), "")+"c_front() { cd /tmp/front; }\n__wo_aliases_mtime=10\n", stdout.String())
+			},
+		},
+		{
+			"We get the autocompletion for nushell and aliases",
+			[]string{"nu", "-t", "dark"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetWorkspacesModTime").Return(time.Unix(0, 10), nil)
+				w.Mock.On("GetCacheDir").Return(cacheDir)
+				w.Mock.On("GetConfigDir").Return("/config")
+				w.Mock.On("GetGlobalConfig", "alias-prefix").Return("c_", nil)
+				w.Mock.On("BuildAliases", "nu", "c_").
+					Return(
+						[]string{
+							`alias c_front = cd "/tmp/front"`,
+						},
+						nil,
+					)
+				w.Mock.On("CreateEnvVariableStatement", "nu", "WO_THEME", "dark").Return(`$env.WO_THEME = "dark"`)
+				return w
+			},
+			func(t *testing.T, stdout *bytes.Buffer, stderr *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Contains(t, stdout.String(), "^wo __complete ...($context | split row \" \" | skip 1)")
+				assert.Contains(t, stdout.String(), "def --env --wrapped wo [...args: string@\"nu-complete wo\"]")
+				assert.Contains(t, stdout.String(), "alias c_front = cd \"/tmp/front\"\n$env.WO_THEME = \"dark\"\n")
+			},
+		},
+		{
+			"An error occurred when getting the modification time of the workspaces",
+			[]string{"bash"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetWorkspacesModTime").Return(time.Time{}, errors.New("an error occurred"))
+				return w
+			},
+			func(t *testing.T, stdout *bytes.Buffer, stderr *bytes.Buffer, err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			"Refreshing the aliases",
+			[]string{"fish", "--refresh"},
+			func(t *testing.T) workspaceManager {
+				w := newMockWorkspaceManager(t)
+				w.Mock.On("GetWorkspacesModTime").Return(time.Unix(0, 10), nil)
+				w.Mock.On("GetCacheDir").Return(cacheDir)
+				w.Mock.On("GetGlobalConfig", "alias-prefix").Return("c_", nil)
+				w.Mock.On("BuildAliases", "fish", "c_").
+					Return(
+						[]string{
+							`alias c_front="cd /tmp/front"`,
+						},
+						nil,
+					)
 				return w
 			},
 			func(t *testing.T, stdout *bytes.Buffer, stderr *bytes.Buffer, err error) {
 				assert.NoError(t, err)
+				assert.Empty(t, stdout.String())
+				content, err := os.ReadFile(filepath.Join(cacheDir, "aliases.fish"))
+				assert.NoError(t, err)
+				assert.Equal(t, "# 10\nalias c_front=\"cd /tmp/front\"\nset -g __wo_aliases_mtime 10\n", string(content))
+				entries, err := os.ReadDir(cacheDir)
+				assert.NoError(t, err)
+				assert.Len(t, entries, 1)
+			},
+		},
+		{
+			"Refreshing the aliases with nushell",
+			[]string{"nu", "--refresh"},
+			func(t *testing.T) workspaceManager {
+				return newMockWorkspaceManager(t)
+			},
+			func(t *testing.T, stdout *bytes.Buffer, stderr *bytes.Buffer, err error) {
+				assert.EqualError(t, err, `the aliases can't be refreshed with "nu"`)
 			},
 		},
 	}
@@ -219,3 +386,14 @@ export WO_THEME=light
 		})
 	}
 }
+
+func TestBuildDispatcher(t *testing.T) {
+	assert.Contains(t, buildDispatcher("bash", "/home/it's/workspaces", "/cache/aliases.bash", ""), "__wo_workspaces_dir='/home/it'\\''s/workspaces'\n__wo_aliases_file='/cache/aliases.bash'\n")
+	assert.Contains(t, buildDispatcher("bash", "/config", "/cache", ""), "PROMPT_COMMAND=")
+	assert.Contains(t, buildDispatcher("zsh", "/config", "/cache", ""), "add-zsh-hook precmd __wo_refresh_aliases")
+	assert.Contains(t, buildDispatcher("fish", `/home/it's/workspaces`, "/cache/aliases.fish", ""), "set -g __wo_workspaces_dir '/home/it\\'s/workspaces'\nset -g __wo_aliases_file '/cache/aliases.fish'\n")
+	assert.NotContains(t, buildDispatcher("nu", "/config", "/cache", ""), "__wo_refresh_aliases")
+	assert.Contains(t, buildDispatcher("bash", "/config", "/cache", ""), "command wo setup bash --refresh || return\n")
+	assert.Contains(t, buildDispatcher("dash", "/config", "/cache", "w_"), "command wo setup dash --refresh -p 'w_' || return\n")
+	assert.Contains(t, buildDispatcher("fish", "/config", "/cache", "w_"), "command wo setup fish --refresh -p 'w_'; or return\n")
+}
@@ -2,14 +2,17 @@ package cmd
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
+	"github.com/antham/wo/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
 func newShowCmd(workspaceManager workspaceManager, completionManager completionManager) *cobra.Command {
-	return &cobra.Command{
+	var format string
+	cmd := &cobra.Command{
 		Use:               "show workspace",
 		Short:             "Show functions and envs available in a workspace",
 		Args:              cobra.ExactArgs(1),
@@ -19,6 +22,13 @@ func newShowCmd(workspaceManager workspaceManager, completionManager completionM
 			if err != nil {
 				return err
 			}
+			format, err = resolveOutputFormat(cmd, workspaceManager, format)
+			if err != nil {
+				return err
+			}
+			if format == jsonOutput {
+				return printJSON(cmd, newWorkspaceOutput(wo, true))
+			}
 			title := titleStyle.
 				Render(fmt.Sprintf("Workspace %s", wo.Name))
 			configTitle := titleStyle.
@@ -38,6 +48,48 @@ func newShowCmd(workspaceManager workspaceManager, completionManager completionM
 					),
 				)
 			}
+			for key, value := range wo.Vars {
+				configs = append(
+					configs,
+					fmt.Sprintf(
+						"%s %s%s",
+						regularStyle.
+							Render("*"),
+						highlightedStyle.
+							Render(fmt.Sprintf("vars.%s", key)),
+						regularStyle.
+							Render(fmt.Sprintf(" : %s", value)),
+					),
+				)
+			}
+			if len(wo.Tags) > 0 {
+				configs = append(
+					configs,
+					fmt.Sprintf(
+						"%s %s%s",
+						regularStyle.
+							Render("*"),
+						highlightedStyle.
+							Render("tags"),
+						regularStyle.
+							Render(fmt.Sprintf(" : %s", strings.Join(wo.Tags, ", "))),
+					),
+				)
+			}
+			if len(wo.Tasks) > 0 {
+				configs = append(
+					configs,
+					fmt.Sprintf(
+						"%s %s%s",
+						regularStyle.
+							Render("*"),
+						highlightedStyle.
+							Render("tasks"),
+						regularStyle.
+							Render(fmt.Sprintf(" : %s", strings.Join(wo.Tasks, ", "))),
+					),
+				)
+			}
 			sort.Strings(configs)
 			functionTitle := titleStyle.
 				Render("Functions")
@@ -48,15 +100,31 @@ func newShowCmd(workspaceManager workspaceManager, completionManager completionM
 					description = regularStyle.
 						Render(fmt.Sprintf(" : %s", f.Description))
 				}
+				var envRules []string
+				if f.Source != "" {
+					envRules = append(envRules, fmt.Sprintf("source: %s", f.Source))
+				}
+				if f.DefaultEnv != "" {
+					envRules = append(envRules, fmt.Sprintf("default env: %s", f.DefaultEnv))
+				}
+				if len(f.Envs) > 0 {
+					envRules = append(envRules, fmt.Sprintf("envs: %s", strings.Join(f.Envs, ", ")))
+				}
+				envRule := ""
+				if len(envRules) > 0 {
+					envRule = regularStyle.
+						Render(fmt.Sprintf(" (%s)", strings.Join(envRules, ", ")))
+				}
 				functions = append(
 					functions,
 					fmt.Sprintf(
-						"%s %s%s",
+						"%s %s%s%s",
 						regularStyle.
 							Render("*"),
 						highlightedStyle.
 							Render(f.Name),
 						description,
+						envRule,
 					),
 				)
 			}
@@ -88,6 +156,13 @@ func newShowCmd(workspaceManager workspaceManager, completionManager completionM
 			cmd.Println(strings.Join(functions, "\n"))
 			cmd.Println()
 			cmd.Println(separator)
+			if dependencies := renderDependencyGraph(wo.Functions.Functions); len(dependencies) > 0 {
+				cmd.Println(titleStyle.Render("Dependencies"))
+				cmd.Println()
+				cmd.Println(strings.Join(dependencies, "\n"))
+				cmd.Println()
+				cmd.Println(separator)
+			}
 			cmd.Println(envTitle)
 			cmd.Println()
 			cmd.Println(strings.Join(envs, "\n"))
@@ -96,4 +171,63 @@ func newShowCmd(workspaceManager workspaceManager, completionManager completionM
 			return nil
 		},
 	}
+	cmd.Flags().StringVarP(&format, "output", "o", textOutput, "Output format, either text or json, defaults to the global config")
+	return cmd
+}
+
+// renderDependencyGraph draws a tree for each function needing other functions
+// and needed by none, a cycle or a missing function is flagged instead of
+// being followed
+func renderDependencyGraph(functions []workspace.Function) []string {
+	byName := map[string]workspace.Function{}
+	needed := map[string]bool{}
+	for _, f := range functions {
+		byName[f.Name] = f
+		for _, n := range f.Needs {
+			needed[n] = true
+		}
+	}
+	lines := []string{}
+	drawn := map[string]bool{}
+	var draw func(f workspace.Function, prefix string, chain []string)
+	draw = func(f workspace.Function, prefix string, chain []string) {
+		drawn[f.Name] = true
+		chain = append(chain, f.Name)
+		for i, name := range f.Needs {
+			branch, indent := "├── ", "│   "
+			if i == len(f.Needs)-1 {
+				branch, indent = "└── ", "    "
+			}
+			dependency, ok := byName[name]
+			line := regularStyle.Render(prefix+branch) + highlightedStyle.Render(name)
+			switch {
+			case slices.Contains(chain, name):
+				lines = append(lines, line+regularStyle.Render(" (cycle)"))
+			case !ok:
+				lines = append(lines, line+regularStyle.Render(" (missing)"))
+			default:
+				lines = append(lines, line)
+				draw(dependency, prefix+indent, chain)
+			}
+		}
+	}
+	drawTree := func(f workspace.Function) {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, highlightedStyle.Render(f.Name))
+		draw(f, "", []string{})
+	}
+	for _, f := range functions {
+		if len(f.Needs) > 0 && !needed[f.Name] {
+			drawTree(f)
+		}
+	}
+	// The functions of a cycle are all needed by another one
+	for _, f := range functions {
+		if len(f.Needs) > 0 && !drawn[f.Name] {
+			drawTree(f)
+		}
+	}
+	return lines
 }
@@ -42,6 +42,7 @@ func TestNewShowCmd(t *testing.T) {
 							"path": "/tmp",
 						},
 					}, nil)
+				w.Mock.On("GetGlobalConfig", "output-format").Return("text", nil)
 				return w, args
 			},
 			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
@@ -80,12 +81,23 @@ No envs defined
 							"app":  "fish",
 							"path": "/tmp",
 						},
+						Tags:  []string{"backend", "go"},
+						Tasks: []string{"makefile"},
+						Vars: map[string]string{
+							"port": "8080",
+							"host": "localhost",
+						},
 						Functions: workspace.Functions{
 							Functions: []workspace.Function{
 								{
 									Name:        "start",
 									Description: "Start a server",
 								},
+								{
+									Name:        "build",
+									Description: "Build the binary",
+									Source:      "makefile",
+								},
 								{
 									Name:        "db-run",
 									Description: "Start a db",
@@ -93,6 +105,16 @@ No envs defined
 								{
 									Name: "stop",
 								},
+								{
+									Name:        "deploy",
+									Description: "Deploy the app",
+									DefaultEnv:  "dev",
+									Envs:        []string{"dev", "prod"},
+								},
+								{
+									Name:       "migrate",
+									DefaultEnv: "dev",
+								},
 							},
 						},
 						Envs: []workspace.Env{
@@ -101,6 +123,7 @@ No envs defined
 							{Name: "prod"},
 						},
 					}, nil)
+				w.Mock.On("GetGlobalConfig", "output-format").Return("text", nil)
 				return w, args
 			},
 			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
@@ -112,13 +135,20 @@ Configuration
 
 * app : fish
 * path : /tmp
+* tags : backend, go
+* tasks : makefile
+* vars.host : localhost
+* vars.port : 8080
 
 ---
 Functions
 
 * start : Start a server
+* build : Build the binary (source: makefile)
 * db-run : Start a db
 * stop
+* deploy : Deploy the app (default env: dev, envs: dev, prod)
+* migrate (default env: dev)
 
 ---
 Envs
@@ -131,6 +161,153 @@ Envs
 `)
 			},
 		},
+		{
+			"Showing a workspace with dependencies between functions",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				args := []string{"api"}
+				w.Mock.On("Get", args[0]).Return(
+					workspace.Workspace{
+						Name: args[0],
+						Config: map[string]string{
+							"app":  "bash",
+							"path": "/tmp",
+						},
+						Functions: workspace.Functions{
+							Functions: []workspace.Function{
+								{Name: "build", Needs: []string{"generate"}},
+								{Name: "deploy", Needs: []string{"build", "test"}},
+								{Name: "generate"},
+								{Name: "ping", Needs: []string{"pong"}},
+								{Name: "pong", Needs: []string{"ping"}},
+								{Name: "test", Needs: []string{"generate", "lint"}},
+							},
+						},
+						Envs: []workspace.Env{
+							{Name: "default"},
+						},
+					}, nil)
+				w.Mock.On("GetGlobalConfig", "output-format").Return("text", nil)
+				return w, args
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, `Workspace api
+
+---
+Configuration
+
+* app : bash
+* path : /tmp
+
+---
+Functions
+
+* build
+* deploy
+* generate
+* ping
+* pong
+* test
+
+---
+Dependencies
+
+deploy
+├── build
+│   └── generate
+└── test
+    ├── generate
+    └── lint (missing)
+
+ping
+└── pong
+    └── ping (cycle)
+
+---
+Envs
+
+* default
+
+---
+`, outBuf.String())
+			},
+		},
+		{
+			"Showing a workspace as json",
+			func(t *testing.T) (workspaceManager, []string) {
+				w := newMockWorkspaceManager(t)
+				args := []string{"api", "-o", "json"}
+				w.Mock.On("Get", args[0]).Return(
+					workspace.Workspace{
+						Name: args[0],
+						Config: map[string]string{
+							"app":  "bash",
+							"path": "/tmp",
+						},
+						Tasks: []string{"npm"},
+						Vars:  map[string]string{"port": "8080"},
+						Functions: workspace.Functions{
+							Functions: []workspace.Function{
+								{
+									Name:   "build",
+									Source: "npm",
+								},
+								{
+									Name:        "run_dev",
+									Description: "Start a server",
+									DefaultEnv:  "dev",
+									Envs:        []string{"dev", "staging"},
+									Needs:       []string{"build"},
+								},
+							},
+						},
+						Envs: []workspace.Env{
+							{Name: "default"},
+						},
+					}, nil)
+				return w, args
+			},
+			func(t *testing.T, outBuf *bytes.Buffer, errBuf *bytes.Buffer, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, `{
+  "name": "api",
+  "config": {
+    "app": "bash",
+    "path": "/tmp"
+  },
+  "tags": [],
+  "tasks": [
+    "npm"
+  ],
+  "vars": {
+    "port": "8080"
+  },
+  "functions": [
+    {
+      "name": "build",
+      "source": "npm"
+    },
+    {
+      "name": "run_dev",
+      "description": "Start a server",
+      "default_env": "dev",
+      "envs": [
+        "dev",
+        "staging"
+      ],
+      "needs": [
+        "build"
+      ]
+    }
+  ],
+  "envs": [
+    "default"
+  ]
+}
+`, outBuf.String())
+			},
+		},
 	}
 	for _, s := range scenarios {
 		t.Run(s.name, func(t *testing.T) {
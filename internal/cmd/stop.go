@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func newStopCmd(workspaceManager workspaceManager) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop id...",
+		Short: "Stop functions running in the background",
+		Long:  "Stop functions started with run --detach, they are terminated and killed if they are still running after a few seconds, the ids are displayed by the jobs command",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids := make([]int, 0, len(args))
+			for _, arg := range args {
+				id, err := parseJobID(arg)
+				if err != nil {
+					return err
+				}
+				ids = append(ids, id)
+			}
+			for _, id := range ids {
+				err := workspaceManager.StopJob(id)
+				if err != nil {
+					return err
+				}
+				cmd.Println(regularStyle.Render(fmt.Sprintf("The job %d is stopped", id)))
+			}
+			return nil
+		},
+	}
+}
+
+func parseJobID(value string) (int, error) {
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf(`"%s" is not a valid job id`, value)
+	}
+	return id, nil
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newTagCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tag",
+		Short: "Manage workspace tags",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+}
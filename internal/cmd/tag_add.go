@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/antham/wo/internal/cmd/internal/validator"
+	"github.com/spf13/cobra"
+)
+
+func newTagAddCmd(workspaceManager workspaceManager, completionManager completionManager) *cobra.Command {
+	return &cobra.Command{
+		Use:               "add workspace tag...",
+		Short:             "Add tags to a workspace",
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completionManager.Process,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			errs := []error{}
+			for _, tag := range args[1:] {
+				errs = append(errs, validator.ValidateName(tag))
+			}
+			return errors.Join(errs...)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := workspaceManager.AddTags(args[0], args[1:])
+			if err != nil {
+				return err
+			}
+			cmd.Printf(
+				regularStyle.Render("Tags '")+highlightedStyle.Render("%s")+regularStyle.Render("' added on workspace '")+highlightedStyle.Render("%s")+regularStyle.Render("'")+"\n",
+				strings.Join(args[1:], ", "), args[0],
+			)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+func newTagRemoveCmd(workspaceManager workspaceManager, completionManager completionManager) *cobra.Command {
+	return &cobra.Command{
+		Use:               "remove workspace tag...",
+		Short:             "Remove tags from a workspace",
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completionManager.Process,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := workspaceManager.RemoveTags(args[0], args[1:])
+			if err != nil {
+				return err
+			}
+			cmd.Printf(
+				regularStyle.Render("Tags '")+highlightedStyle.Render("%s")+regularStyle.Render("' removed from workspace '")+highlightedStyle.Render("%s")+regularStyle.Render("'")+"\n",
+				strings.Join(args[1:], ", "), args[0],
+			)
+			return nil
+		},
+	}
+}
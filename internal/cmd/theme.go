@@ -15,6 +15,18 @@ var separator = lipgloss.NewStyle().
 	Foreground(lipgloss.Color("#B4C5E4")).
 	Render("---")
 
+// prefixStyles color the prefixes of the outputs printed at the same time, one
+// style per output
+var prefixStyles = newPrefixStyles("#3D52D5", "#B8336A", "#2A9D8F", "#C05621", "#6A4C93", "#1B6E3A")
+
+func newPrefixStyles(colors ...string) []lipgloss.Style {
+	styles := []lipgloss.Style{}
+	for _, c := range colors {
+		styles = append(styles, lipgloss.NewStyle().Foreground(lipgloss.Color(c)))
+	}
+	return styles
+}
+
 func applyDarkTheme() {
 	regularStyle = lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#8ECDDD"))
@@ -25,4 +37,5 @@ func applyDarkTheme() {
 	separator = lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#22668D")).
 		Render("---")
+	prefixStyles = newPrefixStyles("#FFFADD", "#FFCC70", "#8ECDDD", "#F4A6C1", "#B5E48C", "#C3A6FF")
 }
@@ -0,0 +1,290 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/antham/wo/internal/shell"
+	"github.com/antham/wo/internal/workspace"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+const pickerHeight = 10
+
+type pickerStep int
+
+const (
+	workspaceStep pickerStep = iota
+	functionStep
+	envStep
+	argsStep
+)
+
+type pickerItem struct {
+	name        string
+	description string
+}
+
+// pickerModel walks through the selection of a workspace, a function, an env
+// and the arguments of the function to run
+type pickerModel struct {
+	step       pickerStep
+	query      string
+	cursor     int
+	workspaces []workspace.Workspace
+	workspace  workspace.Workspace
+	function   workspace.Function
+	env        string
+	args       string
+	argsErr    error
+	done       bool
+	// resolveEnv returns the env the function is run in when no env is
+	// given, it is selected first
+	resolveEnv func(string, string) (string, error)
+}
+
+func newPickerModel(workspaces []workspace.Workspace, resolveEnv func(string, string) (string, error)) pickerModel {
+	return pickerModel{workspaces: workspaces, resolveEnv: resolveEnv}
+}
+
+func (m pickerModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit
+	case tea.KeyEsc:
+		if m.step == workspaceStep {
+			return m, tea.Quit
+		}
+		return m.back(), nil
+	case tea.KeyUp, tea.KeyCtrlP:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case tea.KeyDown, tea.KeyCtrlN:
+		if m.cursor < len(m.items())-1 {
+			m.cursor++
+		}
+	case tea.KeyEnter:
+		return m.selectItem()
+	case tea.KeyBackspace:
+		if m.step == argsStep {
+			m.args = trimLastRune(m.args)
+			m.argsErr = nil
+			break
+		}
+		m.query = trimLastRune(m.query)
+		m.cursor = 0
+	case tea.KeySpace, tea.KeyRunes:
+		if m.step == argsStep {
+			// The text of the key is used as its display form wraps a
+			// paste in brackets and prefixes the alt modifier
+			text := string(keyMsg.Runes)
+			if keyMsg.Type == tea.KeySpace {
+				text = " "
+			}
+			m.args += text
+			m.argsErr = nil
+			break
+		}
+		m.query += string(keyMsg.Runes)
+		m.cursor = 0
+	}
+	return m, nil
+}
+
+func (m pickerModel) selectItem() (tea.Model, tea.Cmd) {
+	if m.step == argsStep {
+		_, m.argsErr = shell.SplitWords(m.args)
+		if m.argsErr != nil {
+			return m, nil
+		}
+		m.done = true
+		return m, tea.Quit
+	}
+	items := m.items()
+	if len(items) == 0 {
+		return m, nil
+	}
+	selected := items[m.cursor].name
+	switch m.step {
+	case workspaceStep:
+		index := slices.IndexFunc(m.workspaces, func(w workspace.Workspace) bool {
+			return w.Name == selected
+		})
+		m.workspace = m.workspaces[index]
+		m.step = functionStep
+	case functionStep:
+		index := slices.IndexFunc(m.workspace.Functions.Functions, func(f workspace.Function) bool {
+			return f.Name == selected
+		})
+		m.function = m.workspace.Functions.Functions[index]
+		m.step = envStep
+		// There is nothing to pick when a single env is available, the
+		// env is then resolved when the function is run
+		if len(m.envs()) < 2 {
+			m.step = argsStep
+		}
+	case envStep:
+		m.env = selected
+		m.step = argsStep
+	}
+	m.query = ""
+	m.cursor = 0
+	if m.step == envStep {
+		m.cursor = max(slices.Index(m.envs(), m.defaultEnv()), 0)
+	}
+	return m, nil
+}
+
+func (m pickerModel) back() pickerModel {
+	switch m.step {
+	case functionStep:
+		m.step = workspaceStep
+	case envStep:
+		m.step = functionStep
+	case argsStep:
+		m.args = ""
+		m.argsErr = nil
+		m.env = ""
+		m.step = envStep
+		if len(m.envs()) < 2 {
+			m.step = functionStep
+		}
+	}
+	m.query = ""
+	m.cursor = 0
+	return m
+}
+
+func (m pickerModel) envs() []string {
+	if len(m.function.Envs) > 0 {
+		return m.function.Envs
+	}
+	envs := []string{}
+	for _, e := range m.workspace.Envs {
+		envs = append(envs, e.Name)
+	}
+	return envs
+}
+
+func (m pickerModel) defaultEnv() string {
+	// The first env is selected when the env can't be resolved
+	env, err := m.resolveEnv(m.workspace.Name, m.function.Name)
+	if err != nil {
+		return ""
+	}
+	return env
+}
+
+func (m pickerModel) items() []pickerItem {
+	items := []pickerItem{}
+	switch m.step {
+	case workspaceStep:
+		for _, w := range m.workspaces {
+			items = append(items, pickerItem{name: w.Name, description: w.Config["path"]})
+		}
+	case functionStep:
+		for _, f := range m.workspace.Functions.Functions {
+			items = append(items, pickerItem{name: f.Name, description: f.Description})
+		}
+	case envStep:
+		for _, env := range m.envs() {
+			items = append(items, pickerItem{name: env})
+		}
+	}
+	return slices.DeleteFunc(items, func(item pickerItem) bool {
+		return !workspace.FuzzyMatch(item.name, m.query)
+	})
+}
+
+func (m pickerModel) View() string {
+	var b strings.Builder
+	switch m.step {
+	case workspaceStep:
+		b.WriteString(titleStyle.Render("Workspaces"))
+	case functionStep:
+		b.WriteString(titleStyle.Render(fmt.Sprintf("Functions of %s", m.workspace.Name)))
+	case envStep:
+		b.WriteString(titleStyle.Render(fmt.Sprintf("Envs of %s", m.function.Name)))
+	case argsStep:
+		b.WriteString(titleStyle.Render(fmt.Sprintf("Arguments of %s", m.function.Name)))
+	}
+	b.WriteString("\n\n")
+	if m.step == argsStep {
+		b.WriteString(regularStyle.Render("> ") + highlightedStyle.Render(m.args) + "\n")
+		if m.argsErr != nil {
+			b.WriteString(regularStyle.Render(fmt.Sprintf("The arguments can't be split: %s", m.argsErr)) + "\n")
+		}
+		b.WriteString("\n" + regularStyle.Render("enter run • esc back • ctrl+c quit") + "\n")
+		return b.String()
+	}
+	b.WriteString(regularStyle.Render("> ") + highlightedStyle.Render(m.query) + "\n")
+	b.WriteString(separator + "\n")
+	items := m.items()
+	if len(items) == 0 {
+		b.WriteString(regularStyle.Render("No matches") + "\n")
+	}
+	start := max(0, m.cursor-pickerHeight+1)
+	for i := start; i < len(items) && i < start+pickerHeight; i++ {
+		line := items[i].name
+		if items[i].description != "" {
+			line += " : " + items[i].description
+		}
+		if i == m.cursor {
+			b.WriteString(highlightedStyle.Render("› "+line) + "\n")
+			continue
+		}
+		b.WriteString(regularStyle.Render("  "+line) + "\n")
+	}
+	b.WriteString("\n" + regularStyle.Render("↑/↓ move • enter select • esc back • ctrl+c quit") + "\n")
+	return b.String()
+}
+
+func trimLastRune(s string) string {
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return s
+	}
+	return string(runes[:len(runes)-1])
+}
+
+// runPicker runs the interactive mode, the help is displayed instead when wo
+// is not attached to a terminal
+func runPicker(cmd *cobra.Command, workspaceManager workspaceManager) error {
+	if !isatty.IsTerminal(os.Stdin.Fd()) || !isatty.IsTerminal(os.Stdout.Fd()) {
+		return cmd.Help()
+	}
+	workspaces, err := workspaceManager.List()
+	if err != nil {
+		return err
+	}
+	if len(workspaces) == 0 {
+		return errors.New("no workspaces defined, create one with the create command")
+	}
+	model, err := tea.NewProgram(newPickerModel(workspaces, workspaceManager.ResolveEnv), tea.WithInput(cmd.InOrStdin()), tea.WithOutput(cmd.ErrOrStderr())).Run()
+	if err != nil {
+		return err
+	}
+	m := model.(pickerModel)
+	if !m.done {
+		return nil
+	}
+	args, err := shell.SplitWords(m.args)
+	if err != nil {
+		return fmt.Errorf("the arguments can't be split: %w", err)
+	}
+	return exitWithFunctionCode(cmd, workspaceManager.RunFunction(m.workspace.Name, m.env, append([]string{m.function.Name}, args...)))
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/antham/wo/internal/workspace"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickerModel(t *testing.T) {
+	workspaces := []workspace.Workspace{
+		{
+			Name:   "api",
+			Config: map[string]string{"path": "/tmp/api"},
+			Functions: workspace.Functions{
+				Functions: []workspace.Function{
+					{Name: "start", Description: "Start the server"},
+					{Name: "deploy", Description: "Deploy the app", DefaultEnv: "prod", Envs: []string{"dev", "prod"}},
+				},
+			},
+			Envs: []workspace.Env{{Name: "default"}},
+		},
+		{
+			Name:   "front",
+			Config: map[string]string{"path": "/tmp/front"},
+			Functions: workspace.Functions{
+				Functions: []workspace.Function{
+					{Name: "build"},
+				},
+			},
+			Envs: []workspace.Env{{Name: "default"}, {Name: "staging"}},
+		},
+		{
+			Name:   "web",
+			Config: map[string]string{"path": "/tmp/web"},
+			Functions: workspace.Functions{
+				Functions: []workspace.Function{
+					{Name: "serve"},
+				},
+			},
+			Envs: []workspace.Env{{Name: "default"}, {Name: "staging"}},
+		},
+	}
+	resolveEnv := func(name string, function string) (string, error) {
+		env, ok := map[string]string{"api/deploy": "prod", "front/build": "default", "web/serve": "staging"}[name+"/"+function]
+		if !ok {
+			return "", errors.New("the function does not exist")
+		}
+		return env, nil
+	}
+	runes := func(s string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+	type scenario struct {
+		name string
+		keys []tea.KeyMsg
+		test func(*testing.T, pickerModel)
+	}
+	scenarios := []scenario{
+		{
+			"Filter the workspaces with a fuzzy query",
+			[]tea.KeyMsg{runes("frt")},
+			func(t *testing.T, m pickerModel) {
+				assert.Equal(t, []pickerItem{{name: "front", description: "/tmp/front"}}, m.items())
+				assert.Contains(t, m.View(), "› front : /tmp/front")
+			},
+		},
+		{
+			"Pick a function whose workspace has a single env",
+			[]tea.KeyMsg{{Type: tea.KeyEnter}, {Type: tea.KeyEnter}, runes("8080"), {Type: tea.KeySpace}, runes("-v"), {Type: tea.KeyBackspace}, {Type: tea.KeyEnter}},
+			func(t *testing.T, m pickerModel) {
+				assert.True(t, m.done)
+				assert.Equal(t, "api", m.workspace.Name)
+				assert.Equal(t, "start", m.function.Name)
+				assert.Equal(t, "", m.env)
+				assert.Equal(t, "8080 -", m.args)
+			},
+		},
+		{
+			"Pick an env among the envs allowed by the function, the default one is selected first",
+			[]tea.KeyMsg{{Type: tea.KeyEnter}, {Type: tea.KeyDown}, {Type: tea.KeyEnter}},
+			func(t *testing.T, m pickerModel) {
+				assert.Equal(t, envStep, m.step)
+				assert.Equal(t, 1, m.cursor)
+				assert.Contains(t, m.View(), "› prod")
+			},
+		},
+		{
+			"Pick an env among the envs of the workspace",
+			[]tea.KeyMsg{runes("fr"), {Type: tea.KeyEnter}, {Type: tea.KeyEnter}, {Type: tea.KeyDown}, {Type: tea.KeyEnter}, {Type: tea.KeyEnter}},
+			func(t *testing.T, m pickerModel) {
+				assert.True(t, m.done)
+				assert.Equal(t, "build", m.function.Name)
+				assert.Equal(t, "staging", m.env)
+			},
+		},
+		{
+			"Pick an env, the env resolved from the global default env is selected first",
+			[]tea.KeyMsg{runes("web"), {Type: tea.KeyEnter}, {Type: tea.KeyEnter}, {Type: tea.KeyEnter}, {Type: tea.KeyEnter}},
+			func(t *testing.T, m pickerModel) {
+				assert.True(t, m.done)
+				assert.Equal(t, "serve", m.function.Name)
+				assert.Equal(t, "staging", m.env)
+			},
+		},
+		{
+			"Arguments which can't be split are refused",
+			[]tea.KeyMsg{{Type: tea.KeyEnter}, {Type: tea.KeyEnter}, runes(`"hello`), {Type: tea.KeyEnter}},
+			func(t *testing.T, m pickerModel) {
+				assert.False(t, m.done)
+				assert.Equal(t, argsStep, m.step)
+				assert.Contains(t, m.View(), "The arguments can't be split: a quote is not closed")
+			},
+		},
+		{
+			"Type the arguments with a paste and an alt modified key",
+			[]tea.KeyMsg{{Type: tea.KeyEnter}, {Type: tea.KeyEnter}, {Type: tea.KeyRunes, Runes: []rune("8080 -v"), Paste: true}, {Type: tea.KeyRunes, Runes: []rune("x"), Alt: true}, {Type: tea.KeyEnter}},
+			func(t *testing.T, m pickerModel) {
+				assert.True(t, m.done)
+				assert.Equal(t, "8080 -vx", m.args)
+			},
+		},
+		{
+			"Go back to the previous step",
+			[]tea.KeyMsg{{Type: tea.KeyEnter}, runes("dep"), {Type: tea.KeyEsc}},
+			func(t *testing.T, m pickerModel) {
+				assert.Equal(t, workspaceStep, m.step)
+				assert.Equal(t, "", m.query)
+			},
+		},
+		{
+			"Skip the env step when going back from the arguments of a function with a single env",
+			[]tea.KeyMsg{{Type: tea.KeyEnter}, {Type: tea.KeyEnter}, {Type: tea.KeyEsc}},
+			func(t *testing.T, m pickerModel) {
+				assert.Equal(t, functionStep, m.step)
+			},
+		},
+		{
+			"Nothing is selected when no items match",
+			[]tea.KeyMsg{runes("db"), {Type: tea.KeyEnter}},
+			func(t *testing.T, m pickerModel) {
+				assert.Equal(t, workspaceStep, m.step)
+				assert.Contains(t, m.View(), "No matches")
+			},
+		},
+		{
+			"Quit the picker",
+			[]tea.KeyMsg{{Type: tea.KeyEnter}, {Type: tea.KeyCtrlC}},
+			func(t *testing.T, m pickerModel) {
+				assert.False(t, m.done)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			var model tea.Model = newPickerModel(workspaces, resolveEnv)
+			for _, key := range s.keys {
+				model, _ = model.Update(key)
+			}
+			s.test(t, model.(pickerModel))
+		})
+	}
+}
+
+func TestRunPickerWithoutTerminal(t *testing.T) {
+	outBuf := &bytes.Buffer{}
+	cmd := &cobra.Command{Use: "wo", Short: "Manage workspaces in shell"}
+	cmd.SetOut(outBuf)
+	assert.NoError(t, runPicker(cmd, newMockWorkspaceManager(t)))
+	assert.Contains(t, outBuf.String(), "Manage workspaces in shell")
+}
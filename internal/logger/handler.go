@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+type handler struct {
+	handler  slog.Handler
+	redactor Redactor
+}
+
+// NewHandler wraps the handler to redact the message and the attributes of
+// every record
+func NewHandler(h slog.Handler, redactor Redactor) slog.Handler {
+	return handler{handler: h, redactor: redactor}
+}
+
+func (h handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h handler) Handle(ctx context.Context, record slog.Record) error {
+	redacted := slog.NewRecord(record.Time, record.Level, h.redactor.Redact(record.Message), record.PC)
+	record.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(h.redactAttr(a))
+		return true
+	})
+	return h.handler.Handle(ctx, redacted)
+}
+
+func (h handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		redacted = append(redacted, h.redactAttr(a))
+	}
+	return handler{handler: h.handler.WithAttrs(redacted), redactor: h.redactor}
+}
+
+func (h handler) WithGroup(name string) slog.Handler {
+	return handler{handler: h.handler.WithGroup(name), redactor: h.redactor}
+}
+
+func (h handler) redactAttr(a slog.Attr) slog.Attr {
+	value := a.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, h.redactor.Redact(value.String()))
+	case slog.KindGroup:
+		attrs := []any{}
+		for _, attr := range value.Group() {
+			attrs = append(attrs, h.redactAttr(attr))
+		}
+		return slog.Group(a.Key, attrs...)
+	case slog.KindAny:
+		switch v := value.Any().(type) {
+		case error:
+			return slog.String(a.Key, h.redactor.Redact(v.Error()))
+		case []string:
+			values := make([]string, 0, len(v))
+			for _, s := range v {
+				values = append(values, h.redactor.Redact(s))
+			}
+			return slog.Any(a.Key, values)
+		default:
+			return slog.String(a.Key, h.redactor.Redact(fmt.Sprintf("%+v", v)))
+		}
+	}
+	return slog.Attr{Key: a.Key, Value: value}
+}
@@ -0,0 +1,37 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	buf := &bytes.Buffer{}
+	r, err := NewRedactor(`corp-[0-9]+`)
+	assert.NoError(t, err)
+	l := slog.New(NewHandler(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}), r))
+
+	l.With(slog.String("command", "TOKEN=abc deploy")).WithGroup("run").Info(
+		"running corp-1",
+		slog.Any("args", []string{"corp-2", "v1"}),
+		slog.Any("error", errors.New("the password=hunter2 is wrong")),
+		slog.Group("env", slog.String("vars", "API_KEY=xyz")),
+		slog.Int("count", 2),
+	)
+	assert.Equal(t, `level=INFO msg="running [REDACTED]" command="TOKEN=[REDACTED] deploy" run.args="[[REDACTED] v1]" run.error="the password=[REDACTED] is wrong" run.env.vars="API_KEY=[REDACTED]" run.count=2`+"\n", buf.String())
+
+	buf.Reset()
+	l.Debug("not enabled")
+	assert.Empty(t, buf.String())
+}
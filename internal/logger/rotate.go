@@ -0,0 +1,100 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// RotatingFile is a log file rotated once it reaches its maximum size, the
+// previous files are kept with a numbered suffix (e.g. wo.log.1), the oldest
+// ones being removed
+type RotatingFile struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	backups int
+	file    *os.File
+	size    int64
+}
+
+// NewRotatingFile opens the log file in append mode
+func NewRotatingFile(path string, maxSize int64, backups int) (*RotatingFile, error) {
+	if maxSize <= 0 {
+		return nil, errors.New("the maximum size of the log file must be greater than 0")
+	}
+	r := &RotatingFile{path: path, maxSize: maxSize, backups: backups}
+	err := r.open()
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		err := r.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+func (r *RotatingFile) open() error {
+	err := os.MkdirAll(filepath.Dir(r.path), 0o777)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Join(err, f.Close())
+	}
+	r.file = f
+	r.size = info.Size()
+	return nil
+}
+
+func (r *RotatingFile) rotate() error {
+	err := r.file.Close()
+	if err != nil {
+		return err
+	}
+	// Renaming a file over the oldest backup drops it
+	for i := r.backups; i > 0; i-- {
+		err = os.Rename(r.backupPath(i-1), r.backupPath(i))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if r.backups == 0 {
+		err = os.Remove(r.path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return r.open()
+}
+
+func (r *RotatingFile) backupPath(index int) string {
+	if index == 0 {
+		return r.path
+	}
+	return fmt.Sprintf("%s.%d", r.path, index)
+}
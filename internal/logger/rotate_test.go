@@ -0,0 +1,38 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logs", "wo.log")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o777))
+	assert.NoError(t, os.WriteFile(path, []byte("0123"), 0o666))
+
+	f, err := NewRotatingFile(path, 10, 2)
+	assert.NoError(t, err)
+	for _, line := range []string{"aaaaa\n", "bbbbb\n", "ccccc\n", "ddddd\n"} {
+		_, err := f.Write([]byte(line))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, f.Close())
+
+	for file, content := range map[string]string{
+		path:        "ddddd\n",
+		path + ".1": "ccccc\n",
+		path + ".2": "bbbbb\n",
+	} {
+		b, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		assert.Equal(t, content, string(b))
+	}
+	assert.NoFileExists(t, path+".3")
+
+	_, err = NewRotatingFile(path, 0, 2)
+	assert.EqualError(t, err, "the maximum size of the log file must be greater than 0")
+}
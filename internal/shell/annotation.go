@@ -0,0 +1,76 @@
+package shell
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+const annotationPrefix = "@"
+
+const (
+	defaultEnvAnnotation    = "default-env"
+	envsAnnotation          = "envs"
+	needsAnnotation         = "needs"
+	sensitiveArgsAnnotation = "sensitive-args"
+)
+
+// getComments returns the block of comment lines located right above the line
+// containing the offset, ordered from the top to the bottom
+func getComments(content []byte, offset int) []string {
+	lineStart := bytes.LastIndexByte(content[:offset], '\n') + 1
+	lines := strings.Split(string(content[:lineStart]), "\n")
+	// The content ends with a line break so the last element is always empty
+	lines = lines[:len(lines)-1]
+	comments := []string{}
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, "#") {
+			break
+		}
+		comments = append([]string{strings.TrimSpace(strings.TrimPrefix(line, "#"))}, comments...)
+	}
+	return comments
+}
+
+// decorate populates the function with the annotations found in the comments,
+// when withDescription is true the closest regular comment is used as the description
+func decorate(function *Function, comments []string, withDescription bool) {
+	for _, comment := range comments {
+		if !strings.HasPrefix(comment, annotationPrefix) {
+			if withDescription {
+				function.Description = comment
+			}
+			continue
+		}
+		key, value, _ := strings.Cut(strings.TrimPrefix(comment, annotationPrefix), " ")
+		values := strings.FieldsFunc(value, func(r rune) bool {
+			return r == ' ' || r == ','
+		})
+		switch key {
+		case defaultEnvAnnotation:
+			if len(values) > 0 {
+				function.DefaultEnv = values[0]
+			}
+		case envsAnnotation:
+			function.Envs = append(function.Envs, values...)
+		case needsAnnotation:
+			function.Needs = append(function.Needs, values...)
+		case sensitiveArgsAnnotation:
+			allArgs := len(values) == 0
+			for _, v := range values {
+				position, err := strconv.Atoi(v)
+				if err == nil && position > 0 {
+					function.SensitiveArgs = append(function.SensitiveArgs, position)
+					continue
+				}
+				// A value which is not a position can't tell which argument
+				// to hide, they are all hidden so nothing secret leaks
+				allArgs = true
+			}
+			if allArgs {
+				function.SensitiveArgs = append(function.SensitiveArgs, AllArgs)
+			}
+		}
+	}
+}
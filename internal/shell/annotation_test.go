@@ -0,0 +1,101 @@
+package shell
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetComments(t *testing.T) {
+	content := []byte(`
+echo e
+
+# First line
+# @default-env staging
+   # Indented comment
+f1() {
+}
+`)
+	assert.Equal(t, []string{"First line", "@default-env staging", "Indented comment"}, getComments(content, bytes.Index(content, []byte("f1()"))))
+	assert.Equal(t, []string{}, getComments(content, 1))
+	assert.Equal(t, []string{}, getComments(content, 0))
+}
+
+func TestDecorate(t *testing.T) {
+	type scenario struct {
+		name            string
+		comments        []string
+		withDescription bool
+		expected        Function
+	}
+	scenarios := []scenario{
+		{
+			"No comments",
+			[]string{},
+			true,
+			Function{Name: "f"},
+		},
+		{
+			"The closest comment is used as the description",
+			[]string{"A section", "A description", "@default-env staging"},
+			true,
+			Function{Name: "f", Description: "A description", DefaultEnv: "staging"},
+		},
+		{
+			"The description is ignored",
+			[]string{"A description", "@default-env staging"},
+			false,
+			Function{Name: "f", DefaultEnv: "staging"},
+		},
+		{
+			"Envs separated by spaces and commas",
+			[]string{"@envs local, staging prod", "@envs dev"},
+			true,
+			Function{Name: "f", Envs: []string{"local", "staging", "prod", "dev"}},
+		},
+		{
+			"Needed functions separated by spaces and commas",
+			[]string{"@needs build, lint", "@needs test"},
+			true,
+			Function{Name: "f", Needs: []string{"build", "lint", "test"}},
+		},
+		{
+			"Sensitive args positions",
+			[]string{"@sensitive-args 1, 3"},
+			true,
+			Function{Name: "f", SensitiveArgs: []int{1, 3}},
+		},
+		{
+			"All args are sensitive when a value is not a position",
+			[]string{"@sensitive-args token"},
+			true,
+			Function{Name: "f", SensitiveArgs: []int{AllArgs}},
+		},
+		{
+			"All args are sensitive when some values are not positions",
+			[]string{"@sensitive-args 2 all -1"},
+			true,
+			Function{Name: "f", SensitiveArgs: []int{2, AllArgs}},
+		},
+		{
+			"All args are sensitive",
+			[]string{"@sensitive-args"},
+			true,
+			Function{Name: "f", SensitiveArgs: []int{AllArgs}},
+		},
+		{
+			"Unknown and empty annotations are ignored",
+			[]string{"@whatever value", "@default-env"},
+			true,
+			Function{Name: "f"},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			f := Function{Name: "f"}
+			decorate(&f, s.comments, s.withDescription)
+			assert.Equal(t, s.expected, f)
+		})
+	}
+}
@@ -12,12 +12,13 @@ func newFishParser() *fishParser {
 
 func (fishParser *fishParser) parse(content []byte) []Function {
 	fs := []Function{}
-	r := regexp.MustCompile(`function\s+(?P<function>[^ |;|\n]+)(?:\s+--?d(?:escription)?\s+(?:"|')(?P<definition>[^(?:'|")]+)(?:"|'))?`).FindAllStringSubmatch(string(content), -1)
-	for _, l := range r {
-		f := Function{Name: l[1]}
-		if len(l) == 3 {
-			f.Description = l[2]
+	r := regexp.MustCompile(`function\s+(?P<function>[^ |;|\n]+)(?:\s+--?d(?:escription)?\s+(?:"|')(?P<definition>[^(?:'|")]+)(?:"|'))?`)
+	for _, l := range r.FindAllSubmatchIndex(content, -1) {
+		f := Function{Name: string(content[l[2]:l[3]])}
+		if l[4] != -1 {
+			f.Description = string(content[l[4]:l[5]])
 		}
+		decorate(&f, getComments(content, l[0]), false)
 		fs = append(fs, f)
 	}
 	return fs
@@ -40,3 +40,24 @@ function f7 -d "function to do something";echo e; end
 		{Name: "f7", Description: "function to do something"},
 	}, functions)
 }
+
+func TestFishParserWithAnnotations(t *testing.T) {
+	fishParser := newFishParser()
+	functions := fishParser.parse([]byte(`
+# A comment
+# @default-env staging
+# @envs staging prod
+# @needs build test
+function deploy -d "Deploy the app"
+	echo e
+end
+
+function test
+	echo e
+end
+`))
+	assert.Equal(t, []Function{
+		{Name: "deploy", Description: "Deploy the app", DefaultEnv: "staging", Envs: []string{"staging", "prod"}, Needs: []string{"build", "test"}},
+		{Name: "test"},
+	}, functions)
+}
@@ -0,0 +1,27 @@
+package shell
+
+import (
+	"regexp"
+)
+
+type kshParser struct{}
+
+func newKshParser() *kshParser {
+	return &kshParser{}
+}
+
+// parse supports both the POSIX syntax "name() {" and the ksh syntax "function name {"
+func (kshParser *kshParser) parse(content []byte) []Function {
+	functions := []Function{}
+	r := regexp.MustCompile(`(?m)^[ \t]*(?:function[ \t]+(?P<ksh>[^\s(){}]+)(?:[ \t]*\(\))?|(?P<posix>[^\s(){}]+)[ \t]*\(\))\s*{`)
+	for _, match := range r.FindAllSubmatchIndex(content, -1) {
+		start, end := match[2], match[3]
+		if start == -1 {
+			start, end = match[4], match[5]
+		}
+		function := Function{Name: string(content[start:end])}
+		decorate(&function, getComments(content, match[0]), true)
+		functions = append(functions, function)
+	}
+	return functions
+}
@@ -0,0 +1,52 @@
+package shell
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKshParser(t *testing.T) {
+	kshParser := newKshParser()
+	functions := kshParser.parse([]byte(`
+f1() {
+    echo e;
+}
+
+function f2 { echo e; }
+
+# This is a description comment
+function f3
+{
+    echo e;
+}
+
+# This is a description comment
+function f4() { echo e;}
+
+f5 () { echo e;}
+`))
+	assert.Equal(t, []Function{
+		{Name: "f1", Description: ""},
+		{Name: "f2", Description: ""},
+		{Name: "f3", Description: "This is a description comment"},
+		{Name: "f4", Description: "This is a description comment"},
+		{Name: "f5", Description: ""},
+	}, functions)
+}
+
+func TestKshParserWithAnnotations(t *testing.T) {
+	kshParser := newKshParser()
+	functions := kshParser.parse([]byte(`
+# Deploy the app
+# @default-env staging
+# @envs staging,prod
+# @needs build test
+function deploy {
+    echo e;
+}
+`))
+	assert.Equal(t, []Function{
+		{Name: "deploy", Description: "Deploy the app", DefaultEnv: "staging", Envs: []string{"staging", "prod"}, Needs: []string{"build", "test"}},
+	}, functions)
+}
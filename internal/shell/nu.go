@@ -0,0 +1,30 @@
+package shell
+
+import (
+	"regexp"
+	"strings"
+)
+
+type nuParser struct{}
+
+func newNuParser() *nuParser {
+	return &nuParser{}
+}
+
+// parse extracts the commands declared with def, the comments right above a
+// command are its documentation in nushell, so they are used as description
+func (nuParser *nuParser) parse(content []byte) []Function {
+	functions := []Function{}
+	r := regexp.MustCompile(`(?m)^[ \t]*(?:export[ \t]+)?def(?:[ \t]+--?[\w-]+)*[ \t]+(?P<function>"[^"]+"|'[^']+'|[^\s\[]+)[ \t]*\[`)
+	for _, match := range r.FindAllSubmatchIndex(content, -1) {
+		name := strings.Trim(string(content[match[2]:match[3]]), `"'`)
+		if strings.Contains(name, " ") {
+			// Subcommands like "main sub" can't be called directly
+			continue
+		}
+		function := Function{Name: name}
+		decorate(&function, getComments(content, match[0]), true)
+		functions = append(functions, function)
+	}
+	return functions
+}
@@ -0,0 +1,57 @@
+package shell
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNuParser(t *testing.T) {
+	nuParser := newNuParser()
+	functions := nuParser.parse([]byte(`
+def f1 [] {
+    print e
+}
+
+def f2 [name: string] { print $name }
+
+# This is a description comment
+def --env f3 [] {
+    cd /tmp
+}
+
+# This is a description comment
+export def "f4" [
+    --verbose (-v)
+] {
+    print e
+}
+
+def "main sub" [] { print e }
+
+def --wrapped f5 [...rest] { print $rest }
+`))
+	assert.Equal(t, []Function{
+		{Name: "f1", Description: ""},
+		{Name: "f2", Description: ""},
+		{Name: "f3", Description: "This is a description comment"},
+		{Name: "f4", Description: "This is a description comment"},
+		{Name: "f5", Description: ""},
+	}, functions)
+}
+
+func TestNuParserWithAnnotations(t *testing.T) {
+	nuParser := newNuParser()
+	functions := nuParser.parse([]byte(`
+# Deploy the app
+# @default-env staging
+# @envs staging prod
+# @needs build test
+def deploy [] {
+    print e
+}
+`))
+	assert.Equal(t, []Function{
+		{Name: "deploy", Description: "Deploy the app", DefaultEnv: "staging", Envs: []string{"staging", "prod"}, Needs: []string{"build", "test"}},
+	}, functions)
+}
@@ -7,19 +7,36 @@ const (
 	bash shellStr = "bash"
 	fish shellStr = "fish"
 	sh   shellStr = "sh"
+	ksh  shellStr = "ksh"
+	dash shellStr = "dash"
+	nu   shellStr = "nu"
 )
 
+// AllArgs is the sensitive argument position standing for every argument
+const AllArgs = 0
+
 type Function struct {
 	Name        string
 	Description string
+	DefaultEnv  string
+	Envs        []string
+	// Needs are the functions to run before the function
+	Needs []string
+	// SensitiveArgs are the 1-based positions of the arguments to redact
+	// from the logs
+	SensitiveArgs []int
 }
 
 func Parse(shell string, content []byte) []Function {
 	switch shell {
-	case string(bash), string(sh), string(zsh):
+	case string(bash), string(sh), string(zsh), string(dash):
 		return newShellParser().parse(content)
+	case string(ksh):
+		return newKshParser().parse(content)
 	case string(fish):
 		return newFishParser().parse(content)
+	case string(nu):
+		return newNuParser().parse(content)
 	}
 	return []Function{}
 }
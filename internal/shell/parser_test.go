@@ -65,4 +65,34 @@ f2 () {
 		{Name: "f1", Description: "This is a function to run"},
 		{Name: "f2", Description: ""},
 	})
+	for _, shell := range []string{"ksh", "dash"} {
+		fs = Parse(shell, []byte(`
+# This is a function to run
+f1() {
+	echo e
+}
+f2 () {
+	echo e
+}
+`))
+		assert.Equal(t, fs, []Function{
+			{Name: "f1", Description: "This is a function to run"},
+			{Name: "f2", Description: ""},
+		})
+	}
+	fs = Parse("nu", []byte(`
+# This is a function to run
+def f1 [] {
+	print e
+}
+
+def f2 [] {
+	print e
+}
+`))
+	assert.Equal(t, fs, []Function{
+		{Name: "f1", Description: "This is a function to run"},
+		{Name: "f2", Description: ""},
+	})
+	assert.Equal(t, []Function{}, Parse("tcsh", []byte("")))
 }
@@ -13,22 +13,14 @@ func newShellParser() *shellParser {
 
 func (shellParser *shellParser) parse(content []byte) []Function {
 	functions := []Function{}
-	r := regexp.MustCompile(`(?m)(?:#\s*(?P<description>.+)\n)?(?:(?P<function>.*)\s*\(\))(?:\s*|\n)?{`)
-	for _, match := range r.FindAllSubmatch(content, -1) {
-		function := Function{}
-		for i, name := range r.SubexpNames() {
-			if i != 0 && name != "" {
-				switch name {
-				case "function":
-					if len(match[i]) == 0 {
-						continue
-					}
-					function.Name = strings.TrimSpace(string(match[i]))
-				case "description":
-					function.Description = strings.TrimSpace(string(match[i]))
-				}
-			}
+	r := regexp.MustCompile(`(?m)(?:(?P<function>.*)\s*\(\))(?:\s*|\n)?{`)
+	for _, match := range r.FindAllSubmatchIndex(content, -1) {
+		name := strings.TrimSpace(string(content[match[2]:match[3]]))
+		if name == "" {
+			continue
 		}
+		function := Function{Name: name}
+		decorate(&function, getComments(content, match[0]), true)
 		functions = append(functions, function)
 	}
 	return functions
@@ -31,3 +31,23 @@ f4() { echo e;}
 		{Name: "f4", Description: "This is a description comment"},
 	}, functions)
 }
+
+func TestShellParserWithAnnotations(t *testing.T) {
+	shellParser := newShellParser()
+	functions := shellParser.parse([]byte(`
+# Deploy the app
+# @default-env staging
+# @envs staging,prod
+deploy() {
+    echo e;
+}
+
+# @envs local
+# Run tests
+test() { echo e;}
+`))
+	assert.Equal(t, []Function{
+		{Name: "deploy", Description: "Deploy the app", DefaultEnv: "staging", Envs: []string{"staging", "prod"}},
+		{Name: "test", Description: "Run tests", Envs: []string{"local"}},
+	}, functions)
+}
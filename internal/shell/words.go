@@ -0,0 +1,65 @@
+package shell
+
+import (
+	"errors"
+	"strings"
+)
+
+// SplitWords splits the command line into words as a POSIX shell does, the
+// quotes and the backslashes keep the blanks inside a word, e.g.
+// `a "b c" d\ e` gives a, b c and d e, no expansion is done
+func SplitWords(line string) ([]string, error) {
+	words := []string{}
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			// A backslash only escapes these characters inside double quotes
+			if quote == '"' && !strings.ContainsRune("\"\\$`", r) {
+				word.WriteRune('\\')
+			}
+			word.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+				continue
+			}
+			word.WriteRune(r)
+		case r == '\\':
+			escaped = true
+			inWord = true
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+				continue
+			}
+			word.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return []string{}, errors.New("a quote is not closed")
+	}
+	if escaped {
+		return []string{}, errors.New("a backslash ends the line")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
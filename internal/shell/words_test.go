@@ -0,0 +1,38 @@
+package shell
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitWords(t *testing.T) {
+	type scenario struct {
+		name  string
+		line  string
+		words []string
+		err   string
+	}
+	scenarios := []scenario{
+		{"Split on blanks", "  8080\t-v  --name=api ", []string{"8080", "-v", "--name=api"}, ""},
+		{"Keep the blanks inside single quotes", `'hello world' 'it''s'`, []string{"hello world", "its"}, ""},
+		{"Keep the blanks inside double quotes", `"hello world" "say \"hi\"" "a\b"`, []string{"hello world", `say "hi"`, `a\b`}, ""},
+		{"Escape a blank with a backslash", `hello\ world \'`, []string{"hello world", "'"}, ""},
+		{"Keep an empty quoted word", `a "" b`, []string{"a", "", "b"}, ""},
+		{"Don't expand the variables", `$HOME '$HOME'`, []string{"$HOME", "$HOME"}, ""},
+		{"Return no words for an empty line", "", []string{}, ""},
+		{"A quote is not closed", `"hello`, []string{}, "a quote is not closed"},
+		{"A backslash ends the line", `hello\`, []string{}, "a backslash ends the line"},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			words, err := SplitWords(s.line)
+			if s.err != "" {
+				assert.EqualError(t, err, s.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, s.words, words)
+		})
+	}
+}
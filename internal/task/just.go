@@ -0,0 +1,55 @@
+package task
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	justRecipeRegexp = regexp.MustCompile(`^@?([A-Za-z_][A-Za-z0-9_-]*)(?:\s[^:]*)?:(?:[^=]|$)`)
+	justDocRegexp    = regexp.MustCompile(`doc\(\s*(?:"([^"]*)"|'([^']*)')\s*\)`)
+)
+
+// parseJustfile returns the public recipes, the description is the doc
+// attribute or the closest comment above the recipe like just --list does
+func parseJustfile(content []byte) ([]Task, error) {
+	tasks := []Task{}
+	comment := ""
+	doc := ""
+	private := false
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case strings.HasPrefix(line, "#"):
+			comment = strings.TrimSpace(strings.TrimLeft(line, "#"))
+			continue
+		case strings.TrimSpace(line) == "":
+			comment = ""
+			continue
+		case strings.HasPrefix(line, "["):
+			private = private || strings.Contains(line, "private")
+			if m := justDocRegexp.FindStringSubmatch(line); m != nil {
+				doc = m[1] + m[2]
+			}
+			continue
+		case strings.HasPrefix(line, "\t") || strings.HasPrefix(line, " "):
+			continue
+		}
+		m := justRecipeRegexp.FindStringSubmatch(line)
+		if m != nil && !private && !strings.HasPrefix(m[1], "_") {
+			description := comment
+			if doc != "" {
+				description = doc
+			}
+			tasks = append(tasks, Task{
+				Name:        m[1],
+				Description: description,
+				Command:     []string{"just", m[1]},
+			})
+		}
+		comment = ""
+		doc = ""
+		private = false
+	}
+	return tasks, nil
+}
@@ -0,0 +1,42 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJustfile(t *testing.T) {
+	content := `set shell := ["bash", "-c"]
+version := "1.0.0"
+alias b := build
+
+# Build the binary
+build: generate
+	go build .
+
+[doc('Run the tests')]
+[group('check')]
+test package="./...":
+	go test {{package}}
+
+# Not a documentation comment
+
+@generate:
+	go generate ./...
+
+[private]
+release:
+	goreleaser
+
+_setup:
+	go mod download
+`
+	tasks, err := parseJustfile([]byte(content))
+	assert.NoError(t, err)
+	assert.Equal(t, []Task{
+		{Name: "build", Description: "Build the binary", Command: []string{"just", "build"}},
+		{Name: "test", Description: "Run the tests", Command: []string{"just", "test"}},
+		{Name: "generate", Command: []string{"just", "generate"}},
+	}, tasks)
+}
@@ -0,0 +1,57 @@
+package task
+
+import (
+	"slices"
+	"strings"
+)
+
+// parseMakefile returns the explicit targets, the description is the comment
+// following the target after ## or the closest comment above it
+func parseMakefile(content []byte) ([]Task, error) {
+	tasks := []Task{}
+	comment := ""
+	inDefine := false
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case inDefine:
+			inDefine = strings.TrimSpace(line) != "endef"
+			continue
+		case strings.HasPrefix(line, "define "):
+			inDefine = true
+			continue
+		case strings.HasPrefix(line, "#"):
+			comment = strings.TrimSpace(strings.TrimLeft(line, "#"))
+			continue
+		case strings.HasPrefix(line, "\t") || strings.HasPrefix(line, " "):
+			continue
+		}
+		description := comment
+		comment = ""
+		targets, rest, ok := strings.Cut(line, ":")
+		// The special targets like .PHONY often sit between a target and its
+		// comment
+		if strings.HasPrefix(targets, ".") {
+			comment = description
+			continue
+		}
+		// The variables, the pattern rules and the file targets are skipped
+		if !ok || strings.ContainsAny(targets, "=$%/") || strings.HasPrefix(rest, "=") || strings.HasPrefix(rest, ":=") {
+			continue
+		}
+		if _, inline, ok := strings.Cut(rest, "##"); ok {
+			description = strings.TrimSpace(inline)
+		}
+		for _, target := range strings.Fields(targets) {
+			if slices.ContainsFunc(tasks, func(t Task) bool { return t.Name == target }) {
+				continue
+			}
+			tasks = append(tasks, Task{
+				Name:        target,
+				Description: description,
+				Command:     []string{"make", target},
+			})
+		}
+	}
+	return tasks, nil
+}
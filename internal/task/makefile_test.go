@@ -0,0 +1,49 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMakefile(t *testing.T) {
+	content := `VERSION := 1.0.0
+BIN ?= wo
+export GOFLAGS = -mod=mod
+
+define HELP
+usage: make target
+endef
+
+# Build the binary
+.PHONY: build
+build: generate
+	go build -o $(BIN) .
+
+test lint: ## Check the project
+	go test ./...
+
+generate:
+	go generate ./...
+
+%.o: %.c
+	cc -c $<
+
+bin/wo: main.go
+	go build -o bin/wo .
+
+# Removed by clean
+
+clean::
+	rm -rf bin
+`
+	tasks, err := parseMakefile([]byte(content))
+	assert.NoError(t, err)
+	assert.Equal(t, []Task{
+		{Name: "build", Description: "Build the binary", Command: []string{"make", "build"}},
+		{Name: "test", Description: "Check the project", Command: []string{"make", "test"}},
+		{Name: "lint", Description: "Check the project", Command: []string{"make", "lint"}},
+		{Name: "generate", Command: []string{"make", "generate"}},
+		{Name: "clean", Command: []string{"make", "clean"}},
+	}, tasks)
+}
@@ -0,0 +1,55 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"slices"
+)
+
+// parsePackageJSON returns the scripts in the order they are defined, the
+// pre and post scripts are skipped as npm runs them along with their script
+func parsePackageJSON(content []byte) ([]Task, error) {
+	pkg := struct {
+		Scripts json.RawMessage `json:"scripts"`
+	}{}
+	err := json.Unmarshal(content, &pkg)
+	if err != nil {
+		return []Task{}, err
+	}
+	tasks := []Task{}
+	if pkg.Scripts == nil {
+		return tasks, nil
+	}
+	// The scripts are decoded token by token as a map would lose their order
+	decoder := json.NewDecoder(bytes.NewReader(pkg.Scripts))
+	token, err := decoder.Token()
+	if err != nil {
+		return []Task{}, err
+	}
+	if token != json.Delim('{') {
+		return []Task{}, errors.New("the scripts must be an object")
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return []Task{}, err
+		}
+		var script string
+		err = decoder.Decode(&script)
+		if err != nil {
+			return []Task{}, err
+		}
+		name := token.(string)
+		tasks = append(tasks, Task{
+			Name:        name,
+			Description: script,
+			Command:     []string{"npm", "run", name, "--"},
+		})
+	}
+	return slices.DeleteFunc(tasks, func(t Task) bool {
+		return slices.ContainsFunc(tasks, func(script Task) bool {
+			return t.Name == "pre"+script.Name || t.Name == "post"+script.Name
+		})
+	}), nil
+}
@@ -0,0 +1,66 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePackageJSON(t *testing.T) {
+	type scenario struct {
+		name    string
+		content string
+		test    func(*testing.T, []Task, error)
+	}
+	scenarios := []scenario{
+		{
+			"Parsing the scripts",
+			`{
+  "name": "front",
+  "scripts": {
+    "prebuild": "rm -rf dist",
+    "build": "tsc -p .",
+    "test:unit": "vitest run",
+    "preview": "vite preview",
+    "postbuild": "cp README.md dist"
+  }
+}`,
+			func(t *testing.T, tasks []Task, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []Task{
+					{Name: "build", Description: "tsc -p .", Command: []string{"npm", "run", "build", "--"}},
+					{Name: "test:unit", Description: "vitest run", Command: []string{"npm", "run", "test:unit", "--"}},
+					{Name: "preview", Description: "vite preview", Command: []string{"npm", "run", "preview", "--"}},
+				}, tasks)
+			},
+		},
+		{
+			"No scripts are defined",
+			`{"name": "front"}`,
+			func(t *testing.T, tasks []Task, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, tasks)
+			},
+		},
+		{
+			"The scripts are not an object",
+			`{"scripts": ["build"]}`,
+			func(t *testing.T, tasks []Task, err error) {
+				assert.EqualError(t, err, "the scripts must be an object")
+			},
+		},
+		{
+			"A script is not a string",
+			`{"scripts": {"build": 1}}`,
+			func(t *testing.T, tasks []Task, err error) {
+				assert.EqualError(t, err, "json: cannot unmarshal number into Go value of type string")
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			tasks, err := parsePackageJSON([]byte(s.content))
+			s.test(t, tasks, err)
+		})
+	}
+}
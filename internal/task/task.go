@@ -0,0 +1,80 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	Makefile = "makefile"
+	NPM      = "npm"
+	Just     = "just"
+	Taskfile = "taskfile"
+)
+
+// Task is a task defined by a task runner, the arguments of the task are
+// appended to its command
+type Task struct {
+	Name        string
+	Description string
+	Command     []string
+}
+
+type source struct {
+	// files are the names of the task file looked for in order
+	files []string
+	parse func([]byte) ([]Task, error)
+}
+
+var sources = map[string]source{
+	Makefile: {[]string{"GNUmakefile", "makefile", "Makefile"}, parseMakefile},
+	NPM:      {[]string{"package.json"}, parsePackageJSON},
+	Just:     {[]string{"justfile", "Justfile", ".justfile"}, parseJustfile},
+	Taskfile: {[]string{"Taskfile.yml", "taskfile.yml", "Taskfile.yaml", "taskfile.yaml", "Taskfile.dist.yml", "taskfile.dist.yml", "Taskfile.dist.yaml", "taskfile.dist.yaml"}, parseTaskfile},
+}
+
+// Sources returns the supported task runners
+func Sources() []string {
+	return []string{Makefile, NPM, Just, Taskfile}
+}
+
+// Find parses the task file of the source found in the folder
+func Find(name string, dir string) ([]Task, error) {
+	s, ok := sources[name]
+	if !ok {
+		return []Task{}, fmt.Errorf(`"%s" is not a supported source, it must be one of: %s`, name, strings.Join(Sources(), ", "))
+	}
+	for _, f := range s.files {
+		content, err := os.ReadFile(filepath.Join(dir, f))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return []Task{}, err
+		}
+		tasks, err := s.parse(content)
+		if err != nil {
+			return []Task{}, fmt.Errorf("the file %s can't be parsed: %w", f, err)
+		}
+		return tasks, nil
+	}
+	return []Task{}, notFoundError{file: s.files[len(s.files)-1], dir: dir}
+}
+
+// notFoundError is returned when the folder has no task file, it matches
+// os.ErrNotExist
+type notFoundError struct {
+	file string
+	dir  string
+}
+
+func (e notFoundError) Error() string {
+	return fmt.Sprintf(`no %s found in "%s"`, e.file, e.dir)
+}
+
+func (e notFoundError) Unwrap() error {
+	return os.ErrNotExist
+}
@@ -0,0 +1,67 @@
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFind(t *testing.T) {
+	type scenario struct {
+		name   string
+		source string
+		setup  func(*testing.T, string)
+		test   func(*testing.T, string, []Task, error)
+	}
+	scenarios := []scenario{
+		{
+			"The source is not supported",
+			"gradle",
+			func(t *testing.T, dir string) {},
+			func(t *testing.T, dir string, tasks []Task, err error) {
+				assert.EqualError(t, err, `"gradle" is not a supported source, it must be one of: makefile, npm, just, taskfile`)
+			},
+		},
+		{
+			"The task file does not exist",
+			Makefile,
+			func(t *testing.T, dir string) {},
+			func(t *testing.T, dir string, tasks []Task, err error) {
+				assert.EqualError(t, err, `no Makefile found in "`+dir+`"`)
+				assert.ErrorIs(t, err, os.ErrNotExist)
+			},
+		},
+		{
+			"The task file can't be parsed",
+			NPM,
+			func(t *testing.T, dir string) {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"scripts": [`), 0o666))
+			},
+			func(t *testing.T, dir string, tasks []Task, err error) {
+				assert.EqualError(t, err, "the file package.json can't be parsed: unexpected end of JSON input")
+			},
+		},
+		{
+			"The first task file found is parsed",
+			Just,
+			func(t *testing.T, dir string) {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, ".justfile"), []byte("hidden:\n\techo hidden\n"), 0o666))
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, "justfile"), []byte("build:\n\tgo build\n"), 0o666))
+			},
+			func(t *testing.T, dir string, tasks []Task, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []Task{{Name: "build", Command: []string{"just", "build"}}}, tasks)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s.setup(t, dir)
+			tasks, err := Find(s.source, dir)
+			s.test(t, dir, tasks, err)
+		})
+	}
+}
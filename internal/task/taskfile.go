@@ -0,0 +1,58 @@
+package task
+
+import (
+	"errors"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// parseTaskfile returns the tasks in the order they are defined, the internal
+// tasks are skipped
+func parseTaskfile(content []byte) ([]Task, error) {
+	tasks := []Task{}
+	// A node is decoded as a map would lose the order of the tasks
+	document := yaml.Node{}
+	err := yaml.Unmarshal(content, &document)
+	if err != nil {
+		return []Task{}, err
+	}
+	if len(document.Content) == 0 {
+		return tasks, nil
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return []Task{}, errors.New("the taskfile must be a map")
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != "tasks" {
+			continue
+		}
+		definitions := root.Content[i+1]
+		if definitions.Kind != yaml.MappingNode {
+			return []Task{}, errors.New("the tasks must be a map")
+		}
+		for j := 0; j+1 < len(definitions.Content); j += 2 {
+			name := definitions.Content[j].Value
+			definition := struct {
+				Desc     string `yaml:"desc"`
+				Internal bool   `yaml:"internal"`
+			}{}
+			// The short syntax defines the commands without any setting
+			if definitions.Content[j+1].Kind == yaml.MappingNode {
+				err := definitions.Content[j+1].Decode(&definition)
+				if err != nil {
+					return []Task{}, err
+				}
+			}
+			if definition.Internal {
+				continue
+			}
+			tasks = append(tasks, Task{
+				Name:        name,
+				Description: definition.Desc,
+				Command:     []string{"task", name, "--"},
+			})
+		}
+	}
+	return tasks, nil
+}
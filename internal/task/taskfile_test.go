@@ -0,0 +1,65 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTaskfile(t *testing.T) {
+	type scenario struct {
+		name    string
+		content string
+		test    func(*testing.T, []Task, error)
+	}
+	scenarios := []scenario{
+		{
+			"Parsing the tasks",
+			`version: '3'
+
+tasks:
+  build:
+    desc: Build the binary
+    cmds:
+      - go build .
+  lint: golangci-lint run
+  setup:
+    internal: true
+    cmds:
+      - go mod download
+  db:migrate:
+    cmds:
+      - migrate up
+`,
+			func(t *testing.T, tasks []Task, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []Task{
+					{Name: "build", Description: "Build the binary", Command: []string{"task", "build", "--"}},
+					{Name: "lint", Command: []string{"task", "lint", "--"}},
+					{Name: "db:migrate", Command: []string{"task", "db:migrate", "--"}},
+				}, tasks)
+			},
+		},
+		{
+			"The file is empty",
+			"",
+			func(t *testing.T, tasks []Task, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, tasks)
+			},
+		},
+		{
+			"The tasks are not a map",
+			"tasks:\n  - build\n",
+			func(t *testing.T, tasks []Task, err error) {
+				assert.EqualError(t, err, "the tasks must be a map")
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			tasks, err := parseTaskfile([]byte(s.content))
+			s.test(t, tasks, err)
+		})
+	}
+}
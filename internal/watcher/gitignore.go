@@ -0,0 +1,111 @@
+package watcher
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+type ignoreRule struct {
+	pattern *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// parseIgnoreRule parses a line of a .gitignore file, false is returned for
+// the blank lines, the comments and the invalid patterns
+func parseIgnoreRule(line string) (ignoreRule, bool) {
+	line = strings.TrimRight(line, " \t")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+	rule := ignoreRule{}
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	pattern, err := compilePathGlob(line)
+	if err != nil {
+		return ignoreRule{}, false
+	}
+	rule.pattern = pattern
+	return rule, true
+}
+
+// ignorer tells whether a path is ignored by the .gitignore files found in
+// the folders, the rules of a folder apply to the paths relative to it
+type ignorer struct {
+	root  string
+	rules map[string][]ignoreRule
+}
+
+func newIgnorer(root string) *ignorer {
+	return &ignorer{root: root, rules: map[string][]ignoreRule{}}
+}
+
+// load reads the .gitignore file of the folder relative to the root
+func (i *ignorer) load(dir string) {
+	delete(i.rules, dir)
+	f, err := os.Open(filepath.Join(i.root, filepath.FromSlash(dir), ".gitignore"))
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		rule, ok := parseIgnoreRule(scanner.Text())
+		if ok {
+			i.rules[dir] = append(i.rules[dir], rule)
+		}
+	}
+}
+
+// isIgnored tells whether the slash separated path relative to the root is
+// ignored, the content of an ignored folder and the .git folder are always
+// ignored
+func (i *ignorer) isIgnored(rel string, isDir bool) bool {
+	if rel == "." || rel == "" {
+		return false
+	}
+	parts := strings.Split(rel, "/")
+	for n := 1; n < len(parts); n++ {
+		if i.matches(strings.Join(parts[:n], "/"), true) {
+			return true
+		}
+	}
+	return i.matches(rel, isDir)
+}
+
+func (i *ignorer) matches(rel string, isDir bool) bool {
+	if path.Base(rel) == ".git" {
+		return true
+	}
+	ignored := false
+	dir := ""
+	for {
+		target := strings.TrimPrefix(rel, dir+"/")
+		if dir == "" {
+			target = rel
+		}
+		for _, rule := range i.rules[dir] {
+			if rule.dirOnly && !isDir {
+				continue
+			}
+			if rule.pattern.MatchString(target) {
+				ignored = !rule.negate
+			}
+		}
+		next, _, found := strings.Cut(target, "/")
+		if !found {
+			return ignored
+		}
+		dir = path.Join(dir, next)
+	}
+}
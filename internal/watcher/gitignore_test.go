@@ -0,0 +1,44 @@
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIgnorer(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "web"), 0o777))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, ".gitignore"), []byte("# Build\n/dist\nnode_modules/\n*.log\n!keep.log\n\ntmp/*\n"), 0o666))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "web", ".gitignore"), []byte("*.js\n"), 0o666))
+	i := newIgnorer(root)
+	i.load("")
+	i.load("web")
+
+	type scenario struct {
+		path    string
+		isDir   bool
+		ignored bool
+	}
+	for _, s := range []scenario{
+		{"main.go", false, false},
+		{".git", true, true},
+		{".git/HEAD", false, true},
+		{"dist", true, true},
+		{"dist/app", false, true},
+		{"web/dist", true, false},
+		{"node_modules", true, true},
+		{"web/node_modules", true, true},
+		{"web/node_modules/a/index.ts", false, true},
+		{"node_modules", false, false},
+		{"server.log", false, true},
+		{"logs/keep.log", false, false},
+		{"tmp/file", false, true},
+		{"web/app.js", false, true},
+		{"app.js", false, false},
+	} {
+		assert.Equal(t, s.ignored, i.isIgnored(s.path, s.isDir), s.path)
+	}
+}
@@ -0,0 +1,73 @@
+package watcher
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// compileGlob converts a glob to a regular expression matching slash
+// separated paths, * and ? don't match a slash whereas ** matches any number
+// of folders
+func compileGlob(glob string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			switch {
+			case strings.HasPrefix(glob[i:], "**/"):
+				b.WriteString("(?:.*/)?")
+				i += 2
+			case strings.HasPrefix(glob[i:], "**"):
+				b.WriteString(".*")
+				i++
+			default:
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end == -1 {
+				return nil, fmt.Errorf(`"%s" is not a valid glob`, glob)
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				b.WriteString(regexp.QuoteMeta(string(glob[i])))
+			}
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	r, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf(`"%s" is not a valid glob`, glob)
+	}
+	return r, nil
+}
+
+// compilePathGlob compiles a glob matched against a path relative to the
+// watched folder, a glob without slash matches the name of the file in any
+// folder
+func compilePathGlob(glob string) (*regexp.Regexp, error) {
+	pattern := strings.TrimPrefix(glob, "./")
+	if strings.Contains(pattern, "/") {
+		pattern = strings.TrimPrefix(pattern, "/")
+	} else {
+		pattern = "**/" + pattern
+	}
+	r, err := compileGlob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf(`"%s" is not a valid glob`, glob)
+	}
+	return r, nil
+}
@@ -0,0 +1,71 @@
+package watcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompilePathGlob(t *testing.T) {
+	type scenario struct {
+		name     string
+		glob     string
+		matches  []string
+		excludes []string
+	}
+	scenarios := []scenario{
+		{
+			"A glob without folder matches the file name in any folder",
+			"*.go",
+			[]string{"main.go", "internal/cmd/run.go"},
+			[]string{"main.go.orig", "README.md"},
+		},
+		{
+			"A glob with a folder is relative to the root",
+			"src/*.go",
+			[]string{"src/main.go"},
+			[]string{"src/cmd/run.go", "lib/src/main.go"},
+		},
+		{
+			"A double star matches any number of folders",
+			"src/**/*.go",
+			[]string{"src/main.go", "src/cmd/run.go", "src/a/b/c.go"},
+			[]string{"main.go", "src/main.ts"},
+		},
+		{
+			"A leading slash or dot slash is ignored",
+			"./src/?.go",
+			[]string{"src/a.go"},
+			[]string{"src/ab.go"},
+		},
+		{
+			"A class of characters",
+			"file[0-9!].txt",
+			[]string{"file1.txt", "dir/file!.txt"},
+			[]string{"filea.txt"},
+		},
+		{
+			"A negated class of characters",
+			"file[!0-9].txt",
+			[]string{"filea.txt"},
+			[]string{"file1.txt"},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			r, err := compilePathGlob(s.glob)
+			assert.NoError(t, err)
+			for _, m := range s.matches {
+				assert.True(t, r.MatchString(m), m)
+			}
+			for _, e := range s.excludes {
+				assert.False(t, r.MatchString(e), e)
+			}
+		})
+	}
+}
+
+func TestCompilePathGlobWithAnInvalidGlob(t *testing.T) {
+	_, err := compilePathGlob("src/[a-")
+	assert.EqualError(t, err, `"src/[a-" is not a valid glob`)
+}
@@ -0,0 +1,164 @@
+package watcher
+
+import (
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watcher notifies the changes of the files of a folder and its subfolders,
+// the files ignored by git are skipped
+type Watcher struct {
+	root     string
+	globs    []*regexp.Regexp
+	ignorer  *ignorer
+	watcher  *fsnotify.Watcher
+	debounce time.Duration
+	changes  chan []string
+	done     chan struct{}
+}
+
+// New watches the folder, only the files matching one of the globs are
+// notified when globs are provided, the changes are notified once no other
+// change happened during the debounce duration
+func New(root string, globs []string, debounce time.Duration) (*Watcher, error) {
+	patterns := []*regexp.Regexp{}
+	for _, g := range globs {
+		p, err := compilePathGlob(g)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, p)
+	}
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	w := &Watcher{
+		root:     root,
+		globs:    patterns,
+		ignorer:  newIgnorer(root),
+		watcher:  fsWatcher,
+		debounce: debounce,
+		changes:  make(chan []string),
+		done:     make(chan struct{}),
+	}
+	err = w.addDir(root)
+	if err != nil {
+		return nil, errors.Join(err, fsWatcher.Close())
+	}
+	go w.run()
+	return w, nil
+}
+
+// Changes returns the channel receiving the paths of the changed files
+// relative to the watched folder
+func (w *Watcher) Changes() <-chan []string {
+	return w.changes
+}
+
+func (w *Watcher) Close() error {
+	close(w.done)
+	return w.watcher.Close()
+}
+
+// addDir watches the folder and its subfolders which are not ignored
+func (w *Watcher) addDir(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		rel := w.relative(path)
+		if w.ignorer.isIgnored(rel, true) {
+			return filepath.SkipDir
+		}
+		w.ignorer.load(rel)
+		return w.watcher.Add(path)
+	})
+}
+
+func (w *Watcher) run() {
+	timer := time.NewTimer(w.debounce)
+	timer.Stop()
+	pending := map[string]struct{}{}
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			rel := w.relative(event.Name)
+			info, err := os.Stat(event.Name)
+			isDir := err == nil && info.IsDir()
+			if w.ignorer.isIgnored(rel, isDir) {
+				continue
+			}
+			if isDir {
+				if event.Has(fsnotify.Create) {
+					err := w.addDir(event.Name)
+					if err != nil {
+						slog.Warn("the folder can't be watched", slog.String("folder", event.Name), slog.Any("error", err))
+					}
+				}
+				continue
+			}
+			if filepath.Base(event.Name) == ".gitignore" {
+				w.ignorer.load(w.relative(filepath.Dir(event.Name)))
+			}
+			if !w.matches(rel) {
+				continue
+			}
+			pending[rel] = struct{}{}
+			timer.Reset(w.debounce)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			slog.Warn("an error occurred when watching the files", slog.Any("error", err))
+		case <-timer.C:
+			files := []string{}
+			for f := range pending {
+				files = append(files, f)
+			}
+			slices.Sort(files)
+			pending = map[string]struct{}{}
+			select {
+			case w.changes <- files:
+			case <-w.done:
+				return
+			}
+		case <-w.done:
+			return
+		}
+	}
+}
+
+func (w *Watcher) matches(rel string) bool {
+	if len(w.globs) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(w.globs, func(r *regexp.Regexp) bool {
+		return r.MatchString(rel)
+	})
+}
+
+func (w *Watcher) relative(path string) string {
+	rel, err := filepath.Rel(w.root, path)
+	if err != nil || rel == "." {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
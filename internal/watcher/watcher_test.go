@@ -0,0 +1,44 @@
+package watcher
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcher(t *testing.T) {
+	root := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "src"), 0o777))
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "build"), 0o777))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, ".gitignore"), []byte("build/\n"), 0o666))
+	w, err := New(root, []string{"*.go"}, 50*time.Millisecond)
+	assert.NoError(t, err)
+	defer w.Close()
+
+	waitChanges := func() []string {
+		select {
+		case files := <-w.Changes():
+			return files
+		case <-time.After(5 * time.Second):
+			return nil
+		}
+	}
+
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "build", "main.go"), []byte(""), 0o666))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "README.md"), []byte(""), 0o666))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "src", "main.go"), []byte(""), 0o666))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte(""), 0o666))
+	assert.Equal(t, []string{"main.go", "src/main.go"}, waitChanges())
+
+	// The folders created are watched
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "src", "cmd"), 0o777))
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "src", "cmd", "run.go"), []byte(""), 0o666))
+	assert.Equal(t, []string{"src/cmd/run.go"}, waitChanges())
+
+	_, err = New(root, []string{"[a-"}, time.Second)
+	assert.EqualError(t, err, `"[a-" is not a valid glob`)
+}
@@ -0,0 +1,79 @@
+package workspace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/antham/wo/internal/shell"
+)
+
+// functionsCacheVersion must be bumped whenever the parsed functions change,
+// so the entries written by a previous version are ignored
+const functionsCacheVersion = 2
+
+type functionsCacheEntry struct {
+	Version   int
+	File      string
+	Shell     string
+	ModTime   time.Time
+	Size      int64
+	Functions []shell.Function
+}
+
+func (e functionsCacheEntry) isFresh(file string, app string, info os.FileInfo) bool {
+	return e.Version == functionsCacheVersion && e.File == file && e.Shell == app && e.ModTime.Equal(info.ModTime()) && e.Size == info.Size()
+}
+
+// parseFunctions parses the functions file, the result is cached and reused
+// as long as the file is not modified, a failure of the cache is never fatal
+func (s WorkspaceManager) parseFunctions(app string, file string) ([]shell.Function, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return []shell.Function{}, err
+	}
+	cacheFile := s.resolveFunctionsCacheFile(file)
+	entry := functionsCacheEntry{}
+	content, err := os.ReadFile(cacheFile)
+	if err == nil && json.Unmarshal(content, &entry) == nil && entry.isFresh(file, app, info) {
+		return entry.Functions, nil
+	}
+	content, err = os.ReadFile(file)
+	if err != nil {
+		return []shell.Function{}, err
+	}
+	entry = functionsCacheEntry{
+		Version:   functionsCacheVersion,
+		File:      file,
+		Shell:     app,
+		ModTime:   info.ModTime(),
+		Size:      info.Size(),
+		Functions: shell.Parse(app, content),
+	}
+	err = s.writeFunctionsCache(cacheFile, entry)
+	if err != nil {
+		slog.Debug("the functions cache can't be written", slog.String("file", cacheFile), slog.String("error", err.Error()))
+	}
+	return entry.Functions, nil
+}
+
+func (s WorkspaceManager) writeFunctionsCache(cacheFile string, entry functionsCacheEntry) error {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(cacheFile), 0o777)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(cacheFile, content, 0o666)
+}
+
+func (s WorkspaceManager) resolveFunctionsCacheFile(file string) string {
+	hash := sha256.Sum256([]byte(file))
+	return filepath.Join(s.cacheDir, "functions", hex.EncodeToString(hash[:])+".json")
+}
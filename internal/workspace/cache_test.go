@@ -0,0 +1,50 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/antham/wo/internal/shell"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFunctions(t *testing.T) {
+	dir := t.TempDir()
+	w := WorkspaceManager{cacheDir: filepath.Join(dir, "cache")}
+	file := filepath.Join(dir, "functions.bash")
+	assert.NoError(t, os.WriteFile(file, []byte("# Start\nstart() {\n}\n"), 0o666))
+
+	functions, err := w.parseFunctions("bash", file)
+	assert.NoError(t, err)
+	assert.Equal(t, []shell.Function{{Name: "start", Description: "Start"}}, functions)
+	assert.FileExists(t, w.resolveFunctionsCacheFile(file))
+
+	// The cached entry is used when the file did not change
+	cachedEntry := func(version int) functionsCacheEntry {
+		info, err := os.Stat(file)
+		assert.NoError(t, err)
+		return functionsCacheEntry{Version: version, File: file, Shell: "bash", ModTime: info.ModTime(), Size: info.Size(), Functions: []shell.Function{{Name: "cached"}}}
+	}
+	assert.NoError(t, w.writeFunctionsCache(w.resolveFunctionsCacheFile(file), cachedEntry(functionsCacheVersion)))
+	functions, err = w.parseFunctions("bash", file)
+	assert.NoError(t, err)
+	assert.Equal(t, []shell.Function{{Name: "cached"}}, functions)
+
+	// The cached entry is ignored when it was written by another version
+	assert.NoError(t, w.writeFunctionsCache(w.resolveFunctionsCacheFile(file), cachedEntry(0)))
+	functions, err = w.parseFunctions("bash", file)
+	assert.NoError(t, err)
+	assert.Equal(t, []shell.Function{{Name: "start", Description: "Start"}}, functions)
+
+	// The file is parsed again when it is modified
+	assert.NoError(t, os.WriteFile(file, []byte("stop() {\n}\n"), 0o666))
+	assert.NoError(t, os.Chtimes(file, time.Now().Add(time.Hour), time.Now().Add(time.Hour)))
+	functions, err = w.parseFunctions("bash", file)
+	assert.NoError(t, err)
+	assert.Equal(t, []shell.Function{{Name: "stop"}}, functions)
+
+	_, err = w.parseFunctions("bash", filepath.Join(dir, "missing.bash"))
+	assert.True(t, os.IsNotExist(err))
+}
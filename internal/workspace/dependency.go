@@ -0,0 +1,45 @@
+package workspace
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// resolveDependencies returns the functions needed by the function in the
+// order they must be run, a function needed several times is run once
+func resolveDependencies(w Workspace, function Function, env string) ([]Function, error) {
+	dependencies := []Function{}
+	resolved := map[string]bool{}
+	var visit func(f Function, chain []string) error
+	visit = func(f Function, chain []string) error {
+		chain = append(chain, f.Name)
+		for _, name := range f.Needs {
+			if index := slices.Index(chain, name); index != -1 {
+				return fmt.Errorf("a dependency cycle was found: %s", strings.Join(append(chain[index:], name), " -> "))
+			}
+			if resolved[name] {
+				continue
+			}
+			dependency, ok := w.Functions.find(name)
+			if !ok {
+				return fmt.Errorf("the function `%s` needed by `%s` does not exist", name, f.Name)
+			}
+			if !dependency.allowsEnv(env) {
+				return fmt.Errorf("the function `%s` needed by `%s` can't be run in the env `%s`, allowed envs are: %s", name, f.Name, env, strings.Join(dependency.Envs, ", "))
+			}
+			err := visit(dependency, chain)
+			if err != nil {
+				return err
+			}
+			resolved[name] = true
+			dependencies = append(dependencies, dependency)
+		}
+		return nil
+	}
+	err := visit(function, []string{})
+	if err != nil {
+		return []Function{}, err
+	}
+	return dependencies, nil
+}
@@ -0,0 +1,94 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveDependencies(t *testing.T) {
+	type scenario struct {
+		name      string
+		functions []Function
+		env       string
+		test      func(*testing.T, []Function, error)
+	}
+	names := func(functions []Function) []string {
+		n := []string{}
+		for _, f := range functions {
+			n = append(n, f.Name)
+		}
+		return n
+	}
+	scenarios := []scenario{
+		{
+			"No dependencies",
+			[]Function{{Name: "deploy"}},
+			"default",
+			func(t *testing.T, functions []Function, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{}, names(functions))
+			},
+		},
+		{
+			"The dependencies are ordered and a shared dependency is returned once",
+			[]Function{
+				{Name: "deploy", Needs: []string{"build", "test"}},
+				{Name: "build", Needs: []string{"generate"}},
+				{Name: "test", Needs: []string{"generate", "build"}},
+				{Name: "generate"},
+			},
+			"default",
+			func(t *testing.T, functions []Function, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"generate", "build", "test"}, names(functions))
+			},
+		},
+		{
+			"A cycle is detected",
+			[]Function{
+				{Name: "deploy", Needs: []string{"build"}},
+				{Name: "build", Needs: []string{"test"}},
+				{Name: "test", Needs: []string{"build"}},
+			},
+			"default",
+			func(t *testing.T, functions []Function, err error) {
+				assert.EqualError(t, err, "a dependency cycle was found: build -> test -> build")
+			},
+		},
+		{
+			"A function needing itself is a cycle",
+			[]Function{{Name: "deploy", Needs: []string{"deploy"}}},
+			"default",
+			func(t *testing.T, functions []Function, err error) {
+				assert.EqualError(t, err, "a dependency cycle was found: deploy -> deploy")
+			},
+		},
+		{
+			"A dependency does not exist",
+			[]Function{{Name: "deploy", Needs: []string{"build"}}},
+			"default",
+			func(t *testing.T, functions []Function, err error) {
+				assert.EqualError(t, err, "the function `build` needed by `deploy` does not exist")
+			},
+		},
+		{
+			"A dependency can't be run in the env",
+			[]Function{
+				{Name: "deploy", Needs: []string{"build"}},
+				{Name: "build", Envs: []string{"dev", "staging"}},
+			},
+			"prod",
+			func(t *testing.T, functions []Function, err error) {
+				assert.EqualError(t, err, "the function `build` needed by `deploy` can't be run in the env `prod`, allowed envs are: dev, staging")
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			w := Workspace{Functions: Functions{Functions: s.functions}}
+			functions, err := resolveDependencies(w, s.functions[0], s.env)
+			s.test(t, functions, err)
+		})
+	}
+}
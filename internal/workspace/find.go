@@ -0,0 +1,95 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Find returns the workspace matching the query, an exact name comes first,
+// then a unique name starting with the query and finally a unique name
+// containing the characters of the query in order, e.g. "fed" for "frontend"
+func (s WorkspaceManager) Find(query string) (Workspace, error) {
+	if query != "" && s.hasWorkspace(query) {
+		return s.getWorkspace(query)
+	}
+	names, err := s.listWorkspaceNames()
+	if err != nil {
+		return Workspace{}, err
+	}
+	for _, match := range []func(string, string) bool{strings.HasPrefix, FuzzyMatch} {
+		matches := []string{}
+		for _, name := range names {
+			if match(name, query) {
+				matches = append(matches, name)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return s.getWorkspace(matches[0])
+		default:
+			return Workspace{}, fmt.Errorf(`"%s" matches several workspaces: %s`, query, strings.Join(matches, ", "))
+		}
+	}
+	return Workspace{}, errors.New("the workspace does not exist")
+}
+
+// ResolvePath returns the path targeted by a workspace query optionally
+// followed by a folder relative to the workspace path, e.g. api/internal/cmd,
+// the folder can't be outside the workspace path
+func (s WorkspaceManager) ResolvePath(target string) (string, error) {
+	query, subpath, _ := strings.Cut(target, "/")
+	w, err := s.Find(query)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(w.Config["path"], subpath)
+	if !isInsidePath(w.Config["path"], path) {
+		return "", fmt.Errorf(`the folder "%s" is outside the workspace "%s"`, subpath, w.Name)
+	}
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return "", fmt.Errorf(`the folder "%s" does not exist in the workspace "%s"`, subpath, w.Name)
+	}
+	return path, nil
+}
+
+// isInsidePath reports whether the path is the root or one of its folders
+func isInsidePath(root string, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && filepath.IsLocal(rel)
+}
+
+func (s WorkspaceManager) listWorkspaceNames() ([]string, error) {
+	names := []string{}
+	entries, err := os.ReadDir(s.getWorkspacesDir())
+	if os.IsNotExist(err) {
+		return names, nil
+	}
+	if err != nil {
+		return names, err
+	}
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+// FuzzyMatch reports whether the characters of the query appear in order in the
+// name
+func FuzzyMatch(name string, query string) bool {
+	for _, r := range query {
+		i := strings.IndexRune(name, r)
+		if i == -1 {
+			return false
+		}
+		name = name[i+1:]
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(filepath.Join(dir, "config")))
+	assert.NoError(t, err)
+	for _, name := range []string{"api", "api-gateway", "frontend", "front-admin"} {
+		assert.NoError(t, w.Create(name, dir, ""))
+	}
+
+	type scenario struct {
+		name  string
+		query string
+		test  func(*testing.T, Workspace, error)
+	}
+	scenarios := []scenario{
+		{
+			"Find a workspace with its exact name",
+			"api",
+			func(t *testing.T, w Workspace, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "api", w.Name)
+			},
+		},
+		{
+			"Find a workspace with a prefix",
+			"api-",
+			func(t *testing.T, w Workspace, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "api-gateway", w.Name)
+			},
+		},
+		{
+			"Find a workspace with a fuzzy query",
+			"fed",
+			func(t *testing.T, w Workspace, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "frontend", w.Name)
+			},
+		},
+		{
+			"Several workspaces match the query",
+			"fr",
+			func(t *testing.T, w Workspace, err error) {
+				assert.EqualError(t, err, `"fr" matches several workspaces: front-admin, frontend`)
+			},
+		},
+		{
+			"No workspace matches the query",
+			"db",
+			func(t *testing.T, w Workspace, err error) {
+				assert.EqualError(t, err, "the workspace does not exist")
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			workspace, err := w.Find(s.query)
+			s.test(t, workspace, err)
+		})
+	}
+}
+
+func TestResolvePath(t *testing.T) {
+	dir := t.TempDir()
+	project := filepath.Join(dir, "project")
+	assert.NoError(t, os.MkdirAll(filepath.Join(project, "internal", "cmd"), 0o777))
+	assert.NoError(t, os.WriteFile(filepath.Join(project, "main.go"), []byte{}, 0o666))
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(filepath.Join(dir, "config")))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Create("api", project, ""))
+
+	path, err := w.ResolvePath("api")
+	assert.NoError(t, err)
+	assert.Equal(t, project, path)
+
+	path, err = w.ResolvePath("ap/internal/cmd")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(project, "internal", "cmd"), path)
+
+	_, err = w.ResolvePath("api/main.go")
+	assert.EqualError(t, err, `the folder "main.go" does not exist in the workspace "api"`)
+
+	_, err = w.ResolvePath("api/../..")
+	assert.EqualError(t, err, `the folder "../.." is outside the workspace "api"`)
+
+	_, err = w.ResolvePath("api/internal/../../config")
+	assert.EqualError(t, err, `the folder "internal/../../config" is outside the workspace "api"`)
+
+	_, err = w.ResolvePath("db/internal")
+	assert.EqualError(t, err, "the workspace does not exist")
+}
@@ -0,0 +1,199 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	GlobalAliasPrefix   = "alias-prefix"
+	GlobalConfirm       = "confirm"
+	GlobalDefaultEnv    = "default-env"
+	GlobalEditor        = "editor"
+	GlobalNotifyAfter   = "notify-after"
+	GlobalNotifyCommand = "notify-command"
+	GlobalNotifyMethod  = "notify-method"
+	GlobalOutputFormat  = "output-format"
+	GlobalRedactPattern = "redact-pattern"
+	GlobalTheme         = "theme"
+)
+
+var globalConfigDefaults = map[string]string{
+	GlobalAliasPrefix:   "c_",
+	GlobalConfirm:       "never",
+	GlobalDefaultEnv:    defaultEnv,
+	GlobalEditor:        "",
+	GlobalNotifyAfter:   "",
+	GlobalNotifyCommand: "",
+	GlobalNotifyMethod:  "bell",
+	GlobalOutputFormat:  "text",
+	GlobalRedactPattern: "",
+	GlobalTheme:         "light",
+}
+
+var globalConfigValidators = map[string]func(string) error{
+	GlobalAliasPrefix: func(value string) error {
+		if !regexp.MustCompile(`^[a-zA-Z0-9_\-]{1,20}$`).MatchString(value) {
+			return fmt.Errorf(`"%s" is not a valid alias prefix, it must comprise letters, numbers, underscore, dash and not have more than 20 characters`, value)
+		}
+		return nil
+	},
+	GlobalConfirm:    oneOf(GlobalConfirm, "always", "never"),
+	GlobalDefaultEnv: notEmpty(GlobalDefaultEnv),
+	GlobalEditor:     notEmpty(GlobalEditor),
+	GlobalNotifyAfter: func(value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return fmt.Errorf(`"%s" is not a valid value for "%s", it must be a duration greater than 0 (e.g. 30s, 5m)`, value, GlobalNotifyAfter)
+		}
+		return nil
+	},
+	GlobalNotifyCommand: notEmpty(GlobalNotifyCommand),
+	GlobalNotifyMethod:  oneOf(GlobalNotifyMethod, "bell", "osc9", "osc777"),
+	GlobalOutputFormat:  oneOf(GlobalOutputFormat, "text", "json"),
+	GlobalRedactPattern: func(value string) error {
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf(`the value of "%s" can't be empty`, GlobalRedactPattern)
+		}
+		_, err := regexp.Compile(value)
+		if err != nil {
+			return fmt.Errorf(`"%s" is not a valid redact pattern: %w`, value, err)
+		}
+		return nil
+	},
+	GlobalTheme: oneOf(GlobalTheme, "light", "dark"),
+}
+
+func oneOf(key string, values ...string) func(string) error {
+	return func(value string) error {
+		if !slices.Contains(values, value) {
+			return fmt.Errorf(`"%s" is not a valid value for "%s", it must be one of: %s`, value, key, strings.Join(values, ", "))
+		}
+		return nil
+	}
+}
+
+func notEmpty(key string) func(string) error {
+	return func(value string) error {
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf(`the value of "%s" can't be empty`, key)
+		}
+		return nil
+	}
+}
+
+func (s WorkspaceManager) GetGlobalConfigKeys() []string {
+	return slices.Sorted(maps.Keys(globalConfigDefaults))
+}
+
+// GetGlobalConfig returns the value of a global config key, an environment
+// variable made of the upper cased key prefixed with WO_ (e.g. WO_THEME)
+// takes precedence over the value defined in the config file
+func (s WorkspaceManager) GetGlobalConfig(key string) (string, error) {
+	if !slices.Contains(s.GetGlobalConfigKeys(), key) {
+		return "", fmt.Errorf(`"%s" is not a valid global config key`, key)
+	}
+	v, err := s.getGlobalViper()
+	if err != nil {
+		return "", err
+	}
+	return v.GetString(key), nil
+}
+
+func (s WorkspaceManager) ListGlobalConfig() (map[string]string, error) {
+	v, err := s.getGlobalViper()
+	if err != nil {
+		return map[string]string{}, err
+	}
+	config := map[string]string{}
+	for _, key := range s.GetGlobalConfigKeys() {
+		config[key] = v.GetString(key)
+	}
+	return config, nil
+}
+
+func (s WorkspaceManager) SetGlobalConfig(key string, value string) error {
+	validate, ok := globalConfigValidators[key]
+	if !ok {
+		return fmt.Errorf(`"%s" is not a valid global config key`, key)
+	}
+	err := validate(value)
+	if err != nil {
+		return err
+	}
+	v, err := s.readGlobalConfigFile()
+	if err != nil {
+		return err
+	}
+	v.Set(key, value)
+	return s.writeGlobalConfigFile(v.AllSettings())
+}
+
+func (s WorkspaceManager) UnsetGlobalConfig(key string) error {
+	if !slices.Contains(s.GetGlobalConfigKeys(), key) {
+		return fmt.Errorf(`"%s" is not a valid global config key`, key)
+	}
+	v, err := s.readGlobalConfigFile()
+	if err != nil {
+		return err
+	}
+	if !v.IsSet(key) {
+		return fmt.Errorf(`the global config key "%s" is not defined`, key)
+	}
+	settings := v.AllSettings()
+	delete(settings, key)
+	return s.writeGlobalConfigFile(settings)
+}
+
+func (s WorkspaceManager) resolveGlobalConfigFile() string {
+	return fmt.Sprintf("%s/config.toml", s.configDir)
+}
+
+func (s WorkspaceManager) getGlobalViper() (*viper.Viper, error) {
+	v, err := s.readGlobalConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	v.SetEnvPrefix(envVariablePrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	for key, value := range globalConfigDefaults {
+		v.SetDefault(key, value)
+		err := v.BindEnv(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func (s WorkspaceManager) readGlobalConfigFile() (*viper.Viper, error) {
+	v := viper.New()
+	v.SetConfigFile(s.resolveGlobalConfigFile())
+	v.SetConfigType("toml")
+	err := v.ReadInConfig()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (s WorkspaceManager) writeGlobalConfigFile(settings map[string]any) error {
+	err := os.MkdirAll(s.configDir, 0o777)
+	if err != nil {
+		return err
+	}
+	v := viper.New()
+	v.SetConfigType("toml")
+	for key, value := range settings {
+		v.Set(key, value)
+	}
+	return v.WriteConfigAs(s.resolveGlobalConfigFile())
+}
@@ -0,0 +1,322 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetGlobalConfig(t *testing.T) {
+	config := &config{}
+	type scenario struct {
+		name  string
+		key   string
+		setup func(*testing.T, WorkspaceManager)
+		test  func(*testing.T, string, error)
+	}
+	scenarios := []scenario{
+		{
+			"Get the default value of a key",
+			GlobalAliasPrefix,
+			func(t *testing.T, w WorkspaceManager) {},
+			func(t *testing.T, value string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "c_", value)
+			},
+		},
+		{
+			"Get a value defined in the config file",
+			GlobalTheme,
+			func(t *testing.T, w WorkspaceManager) {
+				assert.NoError(t, w.SetGlobalConfig(GlobalTheme, "dark"))
+			},
+			func(t *testing.T, value string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "dark", value)
+			},
+		},
+		{
+			"An environment variable takes precedence over the config file",
+			GlobalDefaultEnv,
+			func(t *testing.T, w WorkspaceManager) {
+				assert.NoError(t, w.SetGlobalConfig(GlobalDefaultEnv, "staging"))
+				t.Setenv("WO_DEFAULT_ENV", "local")
+			},
+			func(t *testing.T, value string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "local", value)
+			},
+		},
+		{
+			"Get an unexisting key",
+			"whatever",
+			func(t *testing.T, w WorkspaceManager) {},
+			func(t *testing.T, value string, err error) {
+				assert.EqualError(t, err, `"whatever" is not a valid global config key`)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.RemoveAll(config.getPath(t))
+			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+			assert.NoError(t, err)
+			s.setup(t, w)
+			value, err := w.GetGlobalConfig(s.key)
+			s.test(t, value, err)
+		})
+	}
+}
+
+func TestSetGlobalConfig(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	type scenario struct {
+		name  string
+		key   string
+		value string
+		test  func(*testing.T, error)
+	}
+	scenarios := []scenario{
+		{
+			"Set a value",
+			GlobalOutputFormat,
+			"json",
+			func(t *testing.T, err error) {
+				assert.NoError(t, err)
+				b, err := os.ReadFile(config.getPath(t) + "/config.toml")
+				assert.NoError(t, err)
+				assert.Equal(t, "output-format = 'json'\nshell = 'bash'\n", string(b))
+			},
+		},
+		{
+			"Set an invalid value",
+			GlobalConfirm,
+			"sometimes",
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, `"sometimes" is not a valid value for "confirm", it must be one of: always, never`)
+			},
+		},
+		{
+			"Set an invalid alias prefix",
+			GlobalAliasPrefix,
+			"c$",
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, `"c$" is not a valid alias prefix, it must comprise letters, numbers, underscore, dash and not have more than 20 characters`)
+			},
+		},
+		{
+			"Set an empty editor",
+			GlobalEditor,
+			" ",
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, `the value of "editor" can't be empty`)
+			},
+		},
+		{
+			"Set an invalid redact pattern",
+			GlobalRedactPattern,
+			"token=(",
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, `"token=(" is not a valid redact pattern: error parsing regexp: missing closing ): `+"`token=(`")
+			},
+		},
+		{
+			"Set an invalid notification threshold",
+			GlobalNotifyAfter,
+			"-1m",
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, `"-1m" is not a valid value for "notify-after", it must be a duration greater than 0 (e.g. 30s, 5m)`)
+			},
+		},
+		{
+			"Set an unexisting key",
+			"shell",
+			"fish",
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, `"shell" is not a valid global config key`)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.RemoveAll(config.getPath(t))
+			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+			assert.NoError(t, err)
+			assert.NoError(t, w.Create("test", project.getPath(t), ""))
+			s.test(t, w.SetGlobalConfig(s.key, s.value))
+		})
+	}
+}
+
+func TestSetGlobalConfigBeforeCreatingAWorkspace(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(fmt.Sprintf("%s/wo", config.getPath(t))))
+	assert.NoError(t, err)
+	assert.NoError(t, w.SetGlobalConfig(GlobalTheme, "dark"))
+	assert.NoError(t, w.Create("test", project.getPath(t), ""))
+	b, err := os.ReadFile(config.getPath(t) + "/wo/config.toml")
+	assert.NoError(t, err)
+	assert.Equal(t, "shell = 'bash'\ntheme = 'dark'\n", string(b))
+}
+
+func TestUnsetGlobalConfig(t *testing.T) {
+	config := &config{}
+	type scenario struct {
+		name string
+		key  string
+		test func(*testing.T, WorkspaceManager, error)
+	}
+	scenarios := []scenario{
+		{
+			"Unset a value",
+			GlobalTheme,
+			func(t *testing.T, w WorkspaceManager, err error) {
+				assert.NoError(t, err)
+				value, err := w.GetGlobalConfig(GlobalTheme)
+				assert.NoError(t, err)
+				assert.Equal(t, "light", value)
+				b, err := os.ReadFile(config.getPath(t) + "/config.toml")
+				assert.NoError(t, err)
+				assert.Equal(t, "confirm = 'always'\n", string(b))
+			},
+		},
+		{
+			"Unset an undefined value",
+			GlobalEditor,
+			func(t *testing.T, w WorkspaceManager, err error) {
+				assert.EqualError(t, err, `the global config key "editor" is not defined`)
+			},
+		},
+		{
+			"Unset an unexisting key",
+			"whatever",
+			func(t *testing.T, w WorkspaceManager, err error) {
+				assert.EqualError(t, err, `"whatever" is not a valid global config key`)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.RemoveAll(config.getPath(t))
+			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+			assert.NoError(t, err)
+			assert.NoError(t, w.SetGlobalConfig(GlobalTheme, "dark"))
+			assert.NoError(t, w.SetGlobalConfig(GlobalConfirm, "always"))
+			s.test(t, w, w.UnsetGlobalConfig(s.key))
+		})
+	}
+}
+
+func TestListGlobalConfig(t *testing.T) {
+	config := &config{}
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+	assert.NoError(t, err)
+	assert.NoError(t, w.SetGlobalConfig(GlobalTheme, "dark"))
+	t.Setenv("WO_ALIAS_PREFIX", "go_")
+	values, err := w.ListGlobalConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		GlobalAliasPrefix:   "go_",
+		GlobalConfirm:       "never",
+		GlobalDefaultEnv:    "default",
+		GlobalEditor:        "",
+		GlobalNotifyAfter:   "",
+		GlobalNotifyCommand: "",
+		GlobalNotifyMethod:  "bell",
+		GlobalOutputFormat:  "text",
+		GlobalRedactPattern: "",
+		GlobalTheme:         "dark",
+	}, values)
+}
+
+func TestGlobalEditorOverridesEditor(t *testing.T) {
+	config := &config{}
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+	assert.NoError(t, err)
+	assert.NoError(t, w.SetGlobalConfig(GlobalEditor, "vim"))
+	w, err = NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+	assert.NoError(t, err)
+	assert.Equal(t, "vim", w.editor)
+	w, err = NewWorkspaceManager(WithEditor("", ""), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+	assert.NoError(t, err)
+	assert.Equal(t, "vim", w.editor)
+}
+
+func TestGlobalDefaultEnv(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	type scenario struct {
+		name  string
+		setup func(*testing.T, WorkspaceManager, *MockCommander)
+	}
+	scenarios := []scenario{
+		{
+			"The global default env is used when it exists in the workspace",
+			func(t *testing.T, w WorkspaceManager, exec *MockCommander) {
+				assert.NoError(t, w.CreateEnv("test", "local"))
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, os.Stderr, "-c", fmt.Sprintf("export WO_NAME=test && export WO_ENV=local && source %s/workspaces/test/envs/local.bash && source %s/workspaces/test/functions/functions.bash && run-db", config.getPath(t), config.getPath(t))).Return(nil)
+			},
+		},
+		{
+			"The default env is used when the global default env does not exist in the workspace",
+			func(t *testing.T, w WorkspaceManager, exec *MockCommander) {
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, os.Stderr, "-c", fmt.Sprintf("export WO_NAME=test && export WO_ENV=default && source %s/workspaces/test/envs/default.bash && source %s/workspaces/test/functions/functions.bash && run-db", config.getPath(t), config.getPath(t))).Return(nil)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.RemoveAll(config.getPath(t))
+			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+			assert.NoError(t, err)
+			assert.NoError(t, w.Create("test", project.getPath(t), ""))
+			assert.NoError(t, w.SetGlobalConfig(GlobalDefaultEnv, "local"))
+			assert.NoError(t, os.WriteFile(config.getPath(t)+"/workspaces/test/functions/functions.bash", []byte(`
+run-db() {
+
+}
+`), 0o777))
+			exec := NewMockCommander(t)
+			w.exec = exec
+			s.setup(t, w, exec)
+			assert.NoError(t, w.RunFunction("test", "", []string{"run-db"}))
+		})
+	}
+}
+
+func TestResolveEnv(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Create("test", project.getPath(t), ""))
+	assert.NoError(t, w.CreateEnv("test", "local"))
+	assert.NoError(t, w.CreateEnv("test", "prod"))
+	assert.NoError(t, w.SetGlobalConfig(GlobalDefaultEnv, "local"))
+	assert.NoError(t, os.WriteFile(config.getPath(t)+"/workspaces/test/functions/functions.bash", []byte(`
+run-db() {
+
+}
+
+# @default-env prod
+deploy() {
+
+}
+`), 0o777))
+
+	env, err := w.ResolveEnv("test", "run-db")
+	assert.NoError(t, err)
+	assert.Equal(t, "local", env)
+
+	env, err = w.ResolveEnv("test", "deploy")
+	assert.NoError(t, err)
+	assert.Equal(t, "prod", env)
+
+	_, err = w.ResolveEnv("test", "whatever")
+	assert.EqualError(t, err, "the function `whatever` does not exist")
+}
@@ -0,0 +1,97 @@
+package workspace
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+)
+
+// HistoryEntry is a function run recorded in the history, the ID is the
+// position of the entry in the history starting from 1
+type HistoryEntry struct {
+	ID        int           `json:"-"`
+	Time      time.Time     `json:"time"`
+	Workspace string        `json:"workspace"`
+	Env       string        `json:"env"`
+	Function  string        `json:"function"`
+	Args      []string      `json:"args"`
+	Cwd       string        `json:"cwd"`
+	ExitCode  int           `json:"exit_code"`
+	Duration  time.Duration `json:"duration"`
+	User      string        `json:"user"`
+}
+
+// GetHistory returns the recorded function runs from the oldest to the newest
+func (s WorkspaceManager) GetHistory() ([]HistoryEntry, error) {
+	entries := []HistoryEntry{}
+	f, err := os.Open(s.resolveHistoryFile())
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return entries, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		entry := HistoryEntry{}
+		err := json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return []HistoryEntry{}, errors.New("the history file is corrupted")
+		}
+		entry.ID = len(entries) + 1
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+func (s WorkspaceManager) recordHistory(entry HistoryEntry) error {
+	err := os.MkdirAll(s.stateDir, 0o777)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.resolveHistoryFile(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o666)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(data, '\n'))
+	return errors.Join(err, f.Close())
+}
+
+func (s WorkspaceManager) resolveHistoryFile() string {
+	return filepath.Join(s.stateDir, "history.jsonl")
+}
+
+// newHistoryEntry records the run of the function, the sensitive arguments
+// are redacted as the history is stored in plain text
+func newHistoryEntry(workspace string, env string, function Function, args []string, start time.Time, err error) HistoryEntry {
+	cwd, _ := os.Getwd()
+	return HistoryEntry{
+		Time:      start,
+		Workspace: workspace,
+		Env:       env,
+		Function:  function.Name,
+		Args:      function.redactArgs(args),
+		Cwd:       cwd,
+		ExitCode:  ExitCode(err),
+		Duration:  time.Since(start),
+		User:      currentUser(),
+	}
+}
+
+func currentUser() string {
+	u, err := user.Current()
+	if err != nil {
+		return os.Getenv("USER")
+	}
+	return u.Username
+}
@@ -0,0 +1,74 @@
+package workspace
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHistory(t *testing.T) {
+	dir := t.TempDir()
+	project := filepath.Join(dir, "project")
+	assert.NoError(t, os.MkdirAll(project, 0o777))
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(filepath.Join(dir, "config")))
+	assert.NoError(t, err)
+	w.stateDir = filepath.Join(dir, "state")
+	assert.NoError(t, w.Create("api", project, ""))
+	assert.NoError(t, w.CreateEnv("api", "prod"))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "config", "workspaces", "api", "functions", "functions.bash"), []byte("deploy() {\n}\n"), 0o666))
+
+	entries, err := w.GetHistory()
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	exitError := exec.Command("/bin/sh", "-c", "exit 3").Run()
+	exec := NewMockCommander(t)
+	exec.On("command", mock.Anything, "bash", project, os.Stdout, os.Stderr, "-c", mock.Anything).Return(nil).Once()
+	exec.On("command", mock.Anything, "bash", project, os.Stdout, os.Stderr, "-c", mock.Anything).Return(exitError).Once()
+	exec.On("command", mock.Anything, "bash", project, os.Stdout, os.Stderr, "-c", mock.Anything).Return(errors.New("an error occurred")).Once()
+	w.exec = exec
+	assert.NoError(t, w.RunFunction("api", "", []string{"deploy", "v1"}))
+	assert.Error(t, w.RunFunction("api", "prod", []string{"deploy"}))
+	assert.Error(t, w.RunFunction("api", "prod", []string{"deploy"}))
+	// The run is not recorded when the function does not exist
+	assert.Error(t, w.RunFunction("api", "prod", []string{"build"}))
+
+	entries, err = w.GetHistory()
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, entries[0].ID)
+	assert.Equal(t, "api", entries[0].Workspace)
+	assert.Equal(t, "default", entries[0].Env)
+	assert.Equal(t, "deploy", entries[0].Function)
+	assert.Equal(t, []string{"v1"}, entries[0].Args)
+	assert.Equal(t, cwd, entries[0].Cwd)
+	assert.Equal(t, 0, entries[0].ExitCode)
+	assert.NotEmpty(t, entries[0].User)
+	assert.False(t, entries[0].Time.IsZero())
+	assert.Equal(t, 2, entries[1].ID)
+	assert.Equal(t, "prod", entries[1].Env)
+	assert.Equal(t, []string{}, entries[1].Args)
+	assert.Equal(t, 3, entries[1].ExitCode)
+	assert.Equal(t, -1, entries[2].ExitCode)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "config", "workspaces", "api", "functions", "functions.bash"), []byte("# @sensitive-args 2\nlogin() {\n}\n"), 0o666))
+	exec.On("command", mock.Anything, "bash", project, os.Stdout, os.Stderr, "-c", mock.Anything).Return(nil).Once()
+	assert.NoError(t, w.RunFunction("api", "", []string{"login", "admin", "s3cr3t"}))
+	entries, err = w.GetHistory()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"admin", "[REDACTED]"}, entries[3].Args)
+	content, err := os.ReadFile(filepath.Join(dir, "state", "history.jsonl"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "s3cr3t")
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "state", "history.jsonl"), []byte("{\n"), 0o666))
+	_, err = w.GetHistory()
+	assert.EqualError(t, err, "the history file is corrupted")
+}
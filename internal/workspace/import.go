@@ -0,0 +1,101 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/antham/wo/internal/task"
+)
+
+// ImportResult lists the functions created from the tasks and the tasks
+// skipped because a function of the same name already exists
+type ImportResult struct {
+	Imported []string
+	Skipped  []string
+}
+
+// ImportFunctions creates a function running each task of the task runner
+// found in the path of the workspace, the functions are appended to the
+// functions file
+func (s WorkspaceManager) ImportFunctions(name string, source string) (ImportResult, error) {
+	result := ImportResult{Imported: []string{}, Skipped: []string{}}
+	w, err := s.getWorkspace(name)
+	if err != nil {
+		return result, err
+	}
+	tasks, err := task.Find(source, w.Config["path"])
+	if err != nil {
+		return result, err
+	}
+	if len(tasks) == 0 {
+		return result, fmt.Errorf("no tasks are defined for %s", source)
+	}
+	app := w.Config["app"]
+	definitions := []string{}
+	for _, t := range tasks {
+		functionName := toFunctionName(t.Name)
+		// The functions of the task runners are not in the functions file
+		f, exists := w.Functions.find(functionName)
+		if (exists && f.Source == "") || slices.Contains(result.Imported, functionName) {
+			result.Skipped = append(result.Skipped, t.Name)
+			continue
+		}
+		definitions = append(definitions, createFunctionDefinition(app, functionName, t))
+		result.Imported = append(result.Imported, functionName)
+	}
+	if len(definitions) == 0 {
+		return result, nil
+	}
+	file := s.resolveFunctionFile(name, app)
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return result, err
+	}
+	data := strings.Join(definitions, "\n")
+	switch {
+	case len(content) > 0 && !strings.HasSuffix(string(content), "\n"):
+		data = "\n\n" + data
+	case len(content) > 0 && !strings.HasSuffix(string(content), "\n\n"):
+		data = "\n" + data
+	}
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0o666)
+	if err != nil {
+		return result, err
+	}
+	defer f.Close()
+	_, err = f.WriteString(data)
+	return result, err
+}
+
+// toFunctionName replaces the characters not allowed in the function names of
+// every shell (e.g. build:prod becomes build_prod)
+func toFunctionName(name string) string {
+	return regexp.MustCompile(`[^A-Za-z0-9_]`).ReplaceAllString(name, "_")
+}
+
+// createFunctionDefinition creates a function calling the command of the task
+// with the arguments of the function
+func createFunctionDefinition(app string, name string, t task.Task) string {
+	description := t.Description
+	if description == "" {
+		description = "Run " + strings.Join(slices.DeleteFunc(slices.Clone(t.Command), func(arg string) bool { return arg == "--" }), " ")
+	}
+	// A description starting with @ would be read as an annotation
+	description = strings.TrimLeft(strings.Join(strings.Fields(description), " "), "@ ")
+	command := []string{}
+	for _, arg := range t.Command {
+		command = append(command, quote(app, arg))
+	}
+	switch app {
+	case fish:
+		// The description can't contain quotes in the fish functions
+		description = strings.NewReplacer(`"`, "", `'`, "", `\`, "").Replace(description)
+		return fmt.Sprintf("function %s -d '%s'\n  %s $argv\nend\n", name, description, strings.Join(command, " "))
+	case nu:
+		return fmt.Sprintf("# %s\ndef %s [...args] {\n  ^%s ...$args\n}\n", description, name, strings.Join(command, " "))
+	}
+	return fmt.Sprintf("# %s\n%s() {\n  %s \"$@\"\n}\n", description, name, strings.Join(command, " "))
+}
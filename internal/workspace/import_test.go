@@ -0,0 +1,102 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/antham/wo/internal/shell"
+	"github.com/antham/wo/internal/task"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportFunctions(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Create("api", project.getPath(t), ""))
+	functionFile := filepath.Join(config.getPath(t), "workspaces/api/functions/functions.bash")
+	assert.NoError(t, os.WriteFile(functionFile, []byte("# Run the tests\ntest() {\n  go test ./...\n}"), 0o666))
+
+	_, err = w.ImportFunctions("front", task.Makefile)
+	assert.EqualError(t, err, "the workspace does not exist")
+	_, err = w.ImportFunctions("api", task.Makefile)
+	assert.EqualError(t, err, `no Makefile found in "`+project.getPath(t)+`"`)
+	assert.NoError(t, os.WriteFile(filepath.Join(project.getPath(t), "Makefile"), []byte(".DEFAULT_GOAL := build\n"), 0o666))
+	_, err = w.ImportFunctions("api", task.Makefile)
+	assert.EqualError(t, err, "no tasks are defined for makefile")
+
+	assert.NoError(t, os.WriteFile(filepath.Join(project.getPath(t), "Makefile"), []byte("# Build the binary\nbuild:\n\tgo build .\n\ntest:\n\tgo test ./...\n\ndb.migrate: ## Migrate the database\n\tmigrate up\n\ndb-migrate:\n\tmigrate up\n"), 0o666))
+	result, err := w.ImportFunctions("api", task.Makefile)
+	assert.NoError(t, err)
+	assert.Equal(t, ImportResult{Imported: []string{"build", "db_migrate"}, Skipped: []string{"test", "db-migrate"}}, result)
+	content, err := os.ReadFile(functionFile)
+	assert.NoError(t, err)
+	assert.Equal(t, `# Run the tests
+test() {
+  go test ./...
+}
+
+# Build the binary
+build() {
+  make build "$@"
+}
+
+# Migrate the database
+db_migrate() {
+  make db.migrate "$@"
+}
+`, string(content))
+
+	result, err = w.ImportFunctions("api", task.Makefile)
+	assert.NoError(t, err)
+	assert.Equal(t, ImportResult{Imported: []string{}, Skipped: []string{"build", "test", "db.migrate", "db-migrate"}}, result)
+
+	// The functions of the task runners are not defined in the functions file
+	assert.NoError(t, w.SetConfig("api", map[string]any{"tasks": []string{task.Makefile}}))
+	assert.NoError(t, os.WriteFile(filepath.Join(project.getPath(t), "Makefile"), []byte("build:\n\tgo build .\n\nlint:\n\tgolangci-lint run\n"), 0o666))
+	result, err = w.ImportFunctions("api", task.Makefile)
+	assert.NoError(t, err)
+	assert.Equal(t, ImportResult{Imported: []string{"lint"}, Skipped: []string{"build"}}, result)
+}
+
+func TestCreateFunctionDefinition(t *testing.T) {
+	type scenario struct {
+		app         string
+		description string
+		definition  string
+	}
+	scenarios := []scenario{
+		{
+			bash,
+			"Run vitest\nrun",
+			"# Run vitest run\ntest_unit() {\n  npm run test:unit -- \"$@\"\n}\n",
+		},
+		{
+			dash,
+			"Run vitest\nrun",
+			"# Run vitest run\ntest_unit() {\n  npm run test:unit -- \"$@\"\n}\n",
+		},
+		{
+			fish,
+			"Run 'vitest\nrun'",
+			"function test_unit -d 'Run vitest run'\n  npm run test:unit -- $argv\nend\n",
+		},
+		{
+			nu,
+			"Run vitest\nrun",
+			"# Run vitest run\ndef test_unit [...args] {\n  ^\"npm\" \"run\" \"test:unit\" \"--\" ...$args\n}\n",
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.app, func(t *testing.T) {
+			definition := createFunctionDefinition(s.app, "test_unit", task.Task{Name: "test:unit", Description: s.description, Command: []string{"npm", "run", "test:unit", "--"}})
+			assert.Equal(t, s.definition, definition)
+			assert.Equal(t, []shell.Function{{Name: "test_unit", Description: "Run vitest run"}}, shell.Parse(s.app, []byte(definition)))
+		})
+	}
+	assert.Equal(t, "# Run make build\nbuild() {\n  make build \"$@\"\n}\n", createFunctionDefinition(zsh, "build", task.Task{Name: "build", Command: []string{"make", "build"}}))
+	assert.Equal(t, "# Run task deploy\ndeploy() {\n  task deploy -- \"$@\"\n}\n", createFunctionDefinition(ksh, "deploy", task.Task{Name: "deploy", Command: []string{"task", "deploy", "--"}}))
+	assert.Equal(t, "# the api\nrelease() {\n  just release \"$@\"\n}\n", createFunctionDefinition(sh, "release", task.Task{Name: "release", Description: "@ the api", Command: []string{"just", "release"}}))
+}
@@ -1,5 +1,12 @@
 package workspace
 
+import (
+	"context"
+	"io"
+	"os"
+)
+
 type Commander interface {
-	command(string, ...string) error
+	command(context.Context, string, string, io.Writer, io.Writer, ...string) error
+	start(string, string, *os.File, ...string) (int, error)
 }
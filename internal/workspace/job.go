@@ -0,0 +1,273 @@
+package workspace
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"syscall"
+	"time"
+)
+
+// followDelay is the time between two reads of the log of a followed job
+const followDelay = 200 * time.Millisecond
+
+// jobRetention is the time the log of an exited job is kept after its last
+// write
+const jobRetention = 24 * time.Hour
+
+// Job is a function running in the background
+type Job struct {
+	ID        int       `json:"id"`
+	PID       int       `json:"pid"`
+	Time      time.Time `json:"time"`
+	Workspace string    `json:"workspace"`
+	Env       string    `json:"env"`
+	Function  string    `json:"function"`
+	Args      []string  `json:"args"`
+	// Log is the file receiving the output of the function
+	Log string `json:"log"`
+	// Exited is true once the process of the job is gone, the job is kept
+	// so its output can still be read
+	Exited bool `json:"-"`
+}
+
+// StartJob runs the function in the background in its own session so it keeps
+// running once wo exits, its dependencies are run first in the foreground
+func (s WorkspaceManager) StartJob(name string, env string, functionAndArgs []string, options ...func(*RunOptions)) (Job, error) {
+	runOptions := RunOptions{
+		Stdout:  os.Stdout,
+		Stderr:  os.Stderr,
+		Context: context.Background(),
+	}
+	for _, o := range options {
+		o(&runOptions)
+	}
+	w, function, env, err := s.resolveRun(name, env, functionAndArgs)
+	if err != nil {
+		return Job{}, err
+	}
+	dependencies := []Function{}
+	if !runOptions.NoDeps {
+		dependencies, err = resolveDependencies(w, function, env)
+		if err != nil {
+			return Job{}, err
+		}
+	}
+	err = s.runDependencies(w, env, dependencies, runOptions)
+	if err != nil {
+		return Job{}, err
+	}
+	jobs, err := s.ListJobs()
+	if err != nil {
+		return Job{}, err
+	}
+	err = os.MkdirAll(s.resolveJobsDir(), 0o777)
+	if err != nil {
+		return Job{}, err
+	}
+	job := Job{
+		ID:        1,
+		Time:      time.Now(),
+		Workspace: name,
+		Env:       env,
+		Function:  function.Name,
+		// The job file is stored in plain text
+		Args: function.redactArgs(functionAndArgs[1:]),
+	}
+	for _, j := range jobs {
+		job.ID = max(job.ID, j.ID+1)
+	}
+	// The log file is created exclusively so two jobs started at the same
+	// time don't get the same id
+	var log *os.File
+	for ; ; job.ID++ {
+		job.Log = s.resolveJobFile(job.ID, "log")
+		log, err = os.OpenFile(job.Log, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o666)
+		if !os.IsExist(err) {
+			break
+		}
+	}
+	if err != nil {
+		return Job{}, err
+	}
+	defer log.Close()
+	job.PID, err = s.exec.start(w.Config["app"], w.Config["path"], log, s.appendLoadStatement(w, env, functionAndArgs)...)
+	if err != nil {
+		return Job{}, errors.Join(err, os.Remove(job.Log))
+	}
+	data, err := json.Marshal(job)
+	if err != nil {
+		return Job{}, err
+	}
+	return job, os.WriteFile(s.resolveJobFile(job.ID, "json"), data, 0o666)
+}
+
+// ListJobs returns the jobs ordered by id, the jobs whose process is gone are
+// marked as exited and removed along with their log once the log is older
+// than the retention
+func (s WorkspaceManager) ListJobs() ([]Job, error) {
+	jobs := []Job{}
+	files, err := filepath.Glob(filepath.Join(s.resolveJobsDir(), "*.json"))
+	if err != nil {
+		return jobs, err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return []Job{}, err
+		}
+		job := Job{}
+		err = json.Unmarshal(data, &job)
+		if err != nil {
+			return []Job{}, fmt.Errorf("the job file %s is corrupted", file)
+		}
+		job.Exited = !isProcessRunning(job.PID)
+		if job.Exited {
+			info, err := os.Stat(job.Log)
+			if err == nil && time.Since(info.ModTime()) < jobRetention {
+				jobs = append(jobs, job)
+				continue
+			}
+			err = s.removeJob(job)
+			if err != nil {
+				return []Job{}, err
+			}
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	slices.SortFunc(jobs, func(a, b Job) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return jobs, nil
+}
+
+// StopJob terminates the processes of the job and kills them when they are
+// still running after a delay, the job is then removed with its log
+func (s WorkspaceManager) StopJob(id int) error {
+	job, err := s.getJob(id)
+	if err != nil {
+		return err
+	}
+	// The pid of an exited job may have been given to another process
+	if job.Exited {
+		return s.removeJob(job)
+	}
+	err = syscall.Kill(-job.PID, syscall.SIGTERM)
+	if err != nil && !errors.Is(err, syscall.ESRCH) {
+		return err
+	}
+	if !waitProcess(job.PID, killDelay) {
+		err := syscall.Kill(-job.PID, syscall.SIGKILL)
+		if err != nil && !errors.Is(err, syscall.ESRCH) {
+			return err
+		}
+	}
+	return s.removeJob(job)
+}
+
+// ReadJobLogs writes the last lines of the output of the job, or all of them
+// when lines is 0, the new output is written until the job ends or the
+// context is done when follow is true
+func (s WorkspaceManager) ReadJobLogs(ctx context.Context, id int, lines int, follow bool, w io.Writer) error {
+	job, err := s.getJob(id)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(job.Log)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	err = seekLastLines(f, lines)
+	if err != nil {
+		return err
+	}
+	for {
+		_, err := io.Copy(w, f)
+		if err != nil || !follow {
+			return err
+		}
+		if job.Exited || !isProcessRunning(job.PID) {
+			_, err := io.Copy(w, f)
+			return err
+		}
+		if !sleep(ctx, followDelay) {
+			return nil
+		}
+	}
+}
+
+func (s WorkspaceManager) getJob(id int) (Job, error) {
+	jobs, err := s.ListJobs()
+	if err != nil {
+		return Job{}, err
+	}
+	index := slices.IndexFunc(jobs, func(j Job) bool {
+		return j.ID == id
+	})
+	if index == -1 {
+		return Job{}, fmt.Errorf(`the job "%d" does not exist`, id)
+	}
+	return jobs[index], nil
+}
+
+func (s WorkspaceManager) removeJob(job Job) error {
+	var errs []error
+	for _, file := range []string{s.resolveJobFile(job.ID, "json"), job.Log} {
+		err := os.Remove(file)
+		if err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s WorkspaceManager) resolveJobsDir() string {
+	return filepath.Join(s.stateDir, "jobs")
+}
+
+func (s WorkspaceManager) resolveJobFile(id int, extension string) string {
+	return filepath.Join(s.resolveJobsDir(), fmt.Sprintf("%d.%s", id, extension))
+}
+
+// seekLastLines moves the offset of the file to the beginning of its last
+// lines, the file is read backward to not load a big log in memory
+func seekLastLines(f *os.File, lines int) error {
+	if lines <= 0 {
+		return nil
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	size := info.Size()
+	buffer := make([]byte, 32*1024)
+	found := 0
+	for offset := size; offset > 0; {
+		n := min(int64(len(buffer)), offset)
+		offset -= n
+		_, err := f.ReadAt(buffer[:n], offset)
+		if err != nil {
+			return err
+		}
+		for i := n - 1; i >= 0; i-- {
+			// The line break ending the file doesn't start a line
+			if buffer[i] != '\n' || offset+i == size-1 {
+				continue
+			}
+			found++
+			if found == lines {
+				_, err := f.Seek(offset+i+1, io.SeekStart)
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// startProcess starts a process leading its own session like a job, it is
+// waited for so it doesn't stay a zombie once stopped
+func startProcess(t *testing.T, script string) int {
+	c := exec.Command("/bin/sh", "-c", script)
+	c.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	assert.NoError(t, c.Start())
+	go func() { _ = c.Wait() }()
+	t.Cleanup(func() { _ = syscall.Kill(-c.Process.Pid, syscall.SIGKILL) })
+	return c.Process.Pid
+}
+
+func TestJobs(t *testing.T) {
+	dir := t.TempDir()
+	project := filepath.Join(dir, "project")
+	assert.NoError(t, os.MkdirAll(project, 0o777))
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(filepath.Join(dir, "config")))
+	assert.NoError(t, err)
+	w.stateDir = filepath.Join(dir, "state")
+	assert.NoError(t, w.Create("api", project, ""))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "config", "workspaces", "api", "functions", "functions.bash"), []byte("# @needs build\n# @sensitive-args 2\nserve() {\n}\n\nbuild() {\n}\n"), 0o666))
+
+	jobs, err := w.ListJobs()
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	exec := NewMockCommander(t)
+	exec.On("command", mock.Anything, "bash", project, os.Stdout, os.Stderr, "-c", mock.Anything).Return(nil).Once()
+	exec.On("start", "bash", project, mock.Anything, "-c", mock.Anything).Return(func(shell string, path string, log *os.File, args ...string) (int, error) {
+		_, err := log.WriteString("line 1\nline 2\nline 3\n")
+		assert.NoError(t, err)
+		return startProcess(t, "trap 'exit 0' TERM; while true; do sleep 0.01; done"), nil
+	}).Once()
+	exec.On("start", "bash", project, mock.Anything, "-c", mock.Anything).Return(func(shell string, path string, log *os.File, args ...string) (int, error) {
+		_, err := log.WriteString("build failed\n")
+		assert.NoError(t, err)
+		return startProcess(t, "exit 0"), nil
+	}).Twice()
+	w.exec = exec
+
+	job, err := w.StartJob("api", "", []string{"serve", "8080", "s3cr3t"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, job.ID)
+	assert.Equal(t, "api", job.Workspace)
+	assert.Equal(t, "default", job.Env)
+	assert.Equal(t, "serve", job.Function)
+	assert.Equal(t, []string{"8080", "[REDACTED]"}, job.Args)
+	content, err := os.ReadFile(filepath.Join(dir, "state", "jobs", "1.json"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(content), "s3cr3t")
+	assert.Equal(t, filepath.Join(dir, "state", "jobs", "1.log"), job.Log)
+
+	finished, err := w.StartJob("api", "", []string{"build"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, finished.ID)
+	assert.Eventually(t, func() bool { return !isProcessRunning(finished.PID) }, time.Second, 10*time.Millisecond)
+
+	// The finished job is kept with its output
+	jobs, err = w.ListJobs()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+	assert.Equal(t, job.PID, jobs[0].PID)
+	assert.True(t, job.Time.Equal(jobs[0].Time))
+	assert.False(t, jobs[0].Exited)
+	assert.True(t, jobs[1].Exited)
+
+	output := &bytes.Buffer{}
+	assert.NoError(t, w.ReadJobLogs(context.Background(), 1, 2, false, output))
+	assert.Equal(t, "line 2\nline 3\n", output.String())
+	output.Reset()
+	assert.NoError(t, w.ReadJobLogs(context.Background(), 2, 0, true, output))
+	assert.Equal(t, "build failed\n", output.String())
+
+	// Stopping a finished job removes it with its output
+	assert.NoError(t, w.StopJob(2))
+	assert.NoFileExists(t, finished.Log)
+	assert.EqualError(t, w.ReadJobLogs(context.Background(), 2, 0, false, output), `the job "2" does not exist`)
+	assert.EqualError(t, w.StopJob(2), `the job "2" does not exist`)
+
+	// The finished job is removed once its output is older than the retention
+	expired, err := w.StartJob("api", "", []string{"build"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, expired.ID)
+	assert.Eventually(t, func() bool { return !isProcessRunning(expired.PID) }, time.Second, 10*time.Millisecond)
+	old := time.Now().Add(-jobRetention - time.Minute)
+	assert.NoError(t, os.Chtimes(expired.Log, old, old))
+	jobs, err = w.ListJobs()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.NoFileExists(t, expired.Log)
+	assert.NoFileExists(t, filepath.Join(dir, "state", "jobs", "2.json"))
+
+	assert.NoError(t, w.StopJob(1))
+	assert.False(t, isProcessRunning(job.PID))
+	jobs, err = w.ListJobs()
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+	assert.NoFileExists(t, job.Log)
+}
+
+func TestReadJobLogsFollowing(t *testing.T) {
+	dir := t.TempDir()
+	w := WorkspaceManager{stateDir: dir}
+	assert.NoError(t, os.MkdirAll(w.resolveJobsDir(), 0o777))
+	job := Job{ID: 1, PID: startProcess(t, "sleep 0.3"), Log: w.resolveJobFile(1, "log")}
+	data, err := json.Marshal(job)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(w.resolveJobFile(1, "json"), data, 0o666))
+	assert.NoError(t, os.WriteFile(job.Log, []byte("start\n"), 0o666))
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		f, err := os.OpenFile(job.Log, os.O_APPEND|os.O_WRONLY, 0o666)
+		assert.NoError(t, err)
+		_, err = f.WriteString("end\n")
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+	}()
+
+	// The output written after is read until the job ends
+	output := &bytes.Buffer{}
+	assert.NoError(t, w.ReadJobLogs(context.Background(), 1, 0, true, output))
+	assert.Equal(t, "start\nend\n", output.String())
+}
+
+func TestSeekLastLines(t *testing.T) {
+	type scenario struct {
+		name     string
+		content  string
+		lines    int
+		expected string
+	}
+	scenarios := []scenario{
+		{"All the lines", "a\nb\nc\n", 0, "a\nb\nc\n"},
+		{"The last lines", "a\nb\nc\n", 2, "b\nc\n"},
+		{"The last line is not ended", "a\nb\nc", 2, "b\nc"},
+		{"Fewer lines than requested", "a\nb\n", 5, "a\nb\n"},
+		{"An empty file", "", 3, ""},
+		{"Lines longer than the buffer", string(bytes.Repeat([]byte("a"), 40000)) + "\nb\n", 1, "b\n"},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "log")
+			assert.NoError(t, os.WriteFile(path, []byte(s.content), 0o666))
+			f, err := os.Open(path)
+			assert.NoError(t, err)
+			defer f.Close()
+			assert.NoError(t, seekLastLines(f, s.lines))
+			content, err := io.ReadAll(f)
+			assert.NoError(t, err)
+			assert.Equal(t, s.expected, string(content))
+		})
+	}
+}
+
+func TestCommandStart(t *testing.T) {
+	dir := t.TempDir()
+	log, err := os.Create(filepath.Join(dir, "log"))
+	assert.NoError(t, err)
+	defer log.Close()
+	pid, err := newCommand().start("sh", dir, log, "-c", "pwd; echo error >&2")
+	assert.NoError(t, err)
+	assert.Greater(t, pid, 0)
+	assert.Eventually(t, func() bool {
+		content, err := os.ReadFile(filepath.Join(dir, "log"))
+		return err == nil && string(content) == dir+"\nerror\n"
+	}, time.Second, 10*time.Millisecond)
+	_, err = newCommand().start("whatever", dir, log)
+	assert.EqualError(t, err, `the shell "whatever" can't be found, it must be installed and available in the PATH`)
+}
@@ -2,21 +2,27 @@
 
 package workspace
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+	io "io"
+	os "os"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockCommander is an autogenerated mock type for the Commander type
 type MockCommander struct {
 	mock.Mock
 }
 
-// command provides a mock function with given fields: _a0, _a1
-func (_m *MockCommander) command(_a0 string, _a1 ...string) error {
-	_va := make([]interface{}, len(_a1))
-	for _i := range _a1 {
-		_va[_i] = _a1[_i]
+// command provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4, _a5
+func (_m *MockCommander) command(_a0 context.Context, _a1 string, _a2 string, _a3 io.Writer, _a4 io.Writer, _a5 ...string) error {
+	_va := make([]interface{}, len(_a5))
+	for _i := range _a5 {
+		_va[_i] = _a5[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, _a0)
+	_ca = append(_ca, _a0, _a1, _a2, _a3, _a4)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
@@ -25,8 +31,8 @@ func (_m *MockCommander) command(_a0 string, _a1 ...string) error {
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, ...string) error); ok {
-		r0 = rf(_a0, _a1...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Writer, io.Writer, ...string) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3, _a4, _a5...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -34,6 +40,41 @@ func (_m *MockCommander) command(_a0 string, _a1 ...string) error {
 	return r0
 }
 
+// start provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *MockCommander) start(_a0 string, _a1 string, _a2 *os.File, _a3 ...string) (int, error) {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for start")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, *os.File, ...string) (int, error)); ok {
+		return rf(_a0, _a1, _a2, _a3...)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, *os.File, ...string) int); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, *os.File, ...string) error); ok {
+		r1 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockCommander creates a new instance of MockCommander. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockCommander(t interface {
@@ -0,0 +1,87 @@
+package workspace
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EnvVariable is an environment variable exported before running a function
+type EnvVariable struct {
+	Name  string
+	Value string
+}
+
+// RunPlan describes how a function is run without running it
+type RunPlan struct {
+	Workspace string
+	Env       string
+	Function  string
+	// FunctionArgs are the arguments of the function, the sensitive ones
+	// are redacted
+	FunctionArgs []string
+	Shell        string
+	Path         string
+	Variables    []EnvVariable
+	// Files are the files sourced before running the function, in order
+	Files []string
+	// Args are the arguments given to the shell
+	Args []string
+	// Dependencies are the plans of the functions run before the function,
+	// in order
+	Dependencies []RunPlan
+}
+
+// GetRunPlan resolves the env, the sourced files and the script of a function
+// the same way RunFunction does
+func (s WorkspaceManager) GetRunPlan(name string, env string, functionAndArgs []string, options ...func(*RunOptions)) (RunPlan, error) {
+	runOptions := RunOptions{}
+	for _, o := range options {
+		o(&runOptions)
+	}
+	w, function, env, err := s.resolveRun(name, env, functionAndArgs)
+	if err != nil {
+		return RunPlan{}, err
+	}
+	plan := s.newRunPlan(w, env, functionAndArgs)
+	if runOptions.NoDeps {
+		return plan, nil
+	}
+	dependencies, err := resolveDependencies(w, function, env)
+	if err != nil {
+		return RunPlan{}, err
+	}
+	for _, d := range dependencies {
+		plan.Dependencies = append(plan.Dependencies, s.newRunPlan(w, env, []string{d.Name}))
+	}
+	return plan, nil
+}
+
+func (s WorkspaceManager) newRunPlan(w Workspace, env string, functionAndArgs []string) RunPlan {
+	function, _ := w.Functions.find(functionAndArgs[0])
+	return RunPlan{
+		Workspace:    w.Name,
+		Env:          env,
+		Function:     functionAndArgs[0],
+		FunctionArgs: function.redactArgs(functionAndArgs[1:]),
+		Shell:        w.Config["app"],
+		Path:         w.Config["path"],
+		Variables:    s.resolveVariables(w, env),
+		Files:        s.resolveSourcedFiles(w, env),
+		Args:         s.appendLoadStatement(w, env, functionAndArgs),
+		Dependencies: []RunPlan{},
+	}
+}
+
+// Script returns the commands running the function that can be copied and
+// pasted in the shell of the workspace
+func (p RunPlan) Script() string {
+	command := []string{p.Shell}
+	for _, arg := range p.Args {
+		if strings.HasPrefix(arg, "-") {
+			command = append(command, arg)
+			continue
+		}
+		command = append(command, quote(p.Shell, arg))
+	}
+	return fmt.Sprintf("cd %s\n%s", quote(p.Shell, p.Path), strings.Join(command, " "))
+}
@@ -0,0 +1,99 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRunPlan(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Create("api", project.getPath(t), ""))
+	assert.NoError(t, w.CreateEnv("api", "prod"))
+	assert.NoError(t, w.SetConfig("api", map[string]any{"vars.port": "8080"}))
+	assert.NoError(t, os.WriteFile(config.getPath(t)+"/workspaces/api/functions/functions.bash", []byte("# @default-env prod\n# @needs build\n# @sensitive-args 2\ndeploy() {\n}\n\n# @needs lint\nbuild() {\n}\n\nlint() {\n}\n"), 0o666))
+
+	plan, err := w.GetRunPlan("api", "", []string{"deploy", "v1", "s3cr3t"})
+	assert.NoError(t, err)
+	envFile := fmt.Sprintf("%s/workspaces/api/envs/prod.bash", config.getPath(t))
+	functionFile := fmt.Sprintf("%s/workspaces/api/functions/functions.bash", config.getPath(t))
+	assert.Equal(t, RunPlan{
+		Workspace:    "api",
+		Env:          "prod",
+		Function:     "deploy",
+		FunctionArgs: []string{"v1", "[REDACTED]"},
+		Shell:        "bash",
+		Path:         project.getPath(t),
+		Variables:    []EnvVariable{{"WO_NAME", "api"}, {"WO_ENV", "prod"}, {"WO_VAR_PORT", "8080"}},
+		Files:        []string{envFile, functionFile},
+		Args:         []string{"-c", fmt.Sprintf("export WO_NAME=api && export WO_ENV=prod && export WO_VAR_PORT=8080 && source %s && source %s && deploy v1 s3cr3t", envFile, functionFile)},
+		Dependencies: []RunPlan{
+			{
+				Workspace:    "api",
+				Env:          "prod",
+				Function:     "lint",
+				FunctionArgs: []string{},
+				Shell:        "bash",
+				Path:         project.getPath(t),
+				Variables:    []EnvVariable{{"WO_NAME", "api"}, {"WO_ENV", "prod"}, {"WO_VAR_PORT", "8080"}},
+				Files:        []string{envFile, functionFile},
+				Args:         []string{"-c", fmt.Sprintf("export WO_NAME=api && export WO_ENV=prod && export WO_VAR_PORT=8080 && source %s && source %s && lint", envFile, functionFile)},
+				Dependencies: []RunPlan{},
+			},
+			{
+				Workspace:    "api",
+				Env:          "prod",
+				Function:     "build",
+				FunctionArgs: []string{},
+				Shell:        "bash",
+				Path:         project.getPath(t),
+				Variables:    []EnvVariable{{"WO_NAME", "api"}, {"WO_ENV", "prod"}, {"WO_VAR_PORT", "8080"}},
+				Files:        []string{envFile, functionFile},
+				Args:         []string{"-c", fmt.Sprintf("export WO_NAME=api && export WO_ENV=prod && export WO_VAR_PORT=8080 && source %s && source %s && build", envFile, functionFile)},
+				Dependencies: []RunPlan{},
+			},
+		},
+	}, plan)
+	assert.Equal(t, fmt.Sprintf("cd %s\nbash -c 'export WO_NAME=api && export WO_ENV=prod && export WO_VAR_PORT=8080 && source %s && source %s && deploy v1 s3cr3t'", project.getPath(t), envFile, functionFile), plan.Script())
+
+	plan, err = w.GetRunPlan("api", "", []string{"deploy"}, WithoutDependencies())
+	assert.NoError(t, err)
+	assert.Equal(t, []RunPlan{}, plan.Dependencies)
+
+	_, err = w.GetRunPlan("api", "", []string{"test"})
+	assert.EqualError(t, err, "the function `test` does not exist")
+	_, err = w.GetRunPlan("api", "staging", []string{"deploy"})
+	assert.EqualError(t, err, "the env `staging` does not exist")
+}
+
+func TestRunPlanScript(t *testing.T) {
+	type scenario struct {
+		name     string
+		plan     RunPlan
+		expected string
+	}
+	scenarios := []scenario{
+		{
+			"Fish",
+			RunPlan{Shell: "fish", Path: "/tmp/my project", Args: []string{"-C", "set -x -g WO_NAME api", "-C", "source /tmp/functions.fish", "-c", "deploy it's"}},
+			`cd '/tmp/my project'
+fish -C 'set -x -g WO_NAME api' -C 'source /tmp/functions.fish' -c 'deploy it\'s'`,
+		},
+		{
+			"Nushell",
+			RunPlan{Shell: "nu", Path: "/tmp", Args: []string{"-c", `$env.WO_NAME = "api"; source /tmp/functions.nu; deploy`}},
+			`cd "/tmp"
+nu -c "$env.WO_NAME = \"api\"; source /tmp/functions.nu; deploy"`,
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			assert.Equal(t, s.expected, s.plan.Script())
+		})
+	}
+}
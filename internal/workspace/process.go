@@ -0,0 +1,160 @@
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// TimeoutExitCode is the exit code of a function stopped by its timeout, it
+// is the one used by the timeout command
+const TimeoutExitCode = 124
+
+// killDelay is the time left to a function to stop once terminated before it
+// is killed
+const killDelay = 5 * time.Second
+
+var (
+	// ErrTimeout is returned when a function runs for longer than its timeout
+	ErrTimeout = errors.New("the function has timed out")
+	// ErrInterrupted is returned when a function is stopped by a signal
+	// received by wo or by the function when it owns the terminal
+	ErrInterrupted = errors.New("the function has been interrupted")
+	// ErrDependency is returned when a function needed by the function to run
+	// fails
+	ErrDependency = errors.New("the function is not run because of the failure of its dependency")
+)
+
+// ExitCode returns the exit code matching the result of a function, a function
+// killed by a signal exits with 128 plus the number of the signal like in the
+// shells and -1 is returned when the function could not be run
+func ExitCode(err error) int {
+	var exitError *exec.ExitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, ErrTimeout):
+		return TimeoutExitCode
+	case errors.As(err, &exitError):
+		status, ok := exitError.Sys().(syscall.WaitStatus)
+		if ok && status.Signaled() {
+			return 128 + int(status.Signal())
+		}
+		return exitError.ExitCode()
+	}
+	return -1
+}
+
+// isRetryable returns true when the function failed or timed out, a function
+// interrupted or that could not be run is not run again
+func isRetryable(err error) bool {
+	var exitError *exec.ExitError
+	return !errors.Is(err, ErrInterrupted) && !isInterruptedExit(err) && (errors.Is(err, ErrTimeout) || errors.As(err, &exitError))
+}
+
+// isInterruptedExit tells whether the shell was stopped by an interrupt or a
+// terminate signal, a function owning the terminal receives ctrl-c instead of
+// wo
+func isInterruptedExit(err error) bool {
+	var exitError *exec.ExitError
+	if !errors.As(err, &exitError) {
+		return false
+	}
+	code := ExitCode(err)
+	return code == 128+int(syscall.SIGINT) || code == 128+int(syscall.SIGTERM)
+}
+
+func failureReason(err error) string {
+	if errors.Is(err, ErrTimeout) {
+		return err.Error()
+	}
+	return fmt.Sprintf("the function failed with exit code %d", ExitCode(err))
+}
+
+// sleep waits for the duration, false is returned when the context is done
+// before
+func sleep(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-time.After(d):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// isProcessRunning tells whether the process leading its own group is still
+// running, a pid reused by another process is unlikely to lead a group
+func isProcessRunning(pid int) bool {
+	pgid, err := syscall.Getpgid(pid)
+	return err == nil && pgid == pid
+}
+
+// waitProcess waits for a process which is not a child of wo to exit, false is
+// returned when it is still running after the timeout
+func waitProcess(pid int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for isProcessRunning(pid) {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return true
+}
+
+// setProcessGroup runs the shell in its own process group so the signals and
+// the timeout reach every process started by the function, the group is put
+// in the foreground when the function is interactive and wo is attached to a
+// terminal
+func setProcessGroup(command *exec.Cmd, interactive bool) {
+	command.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if !interactive {
+		return
+	}
+	fd := int(os.Stdin.Fd())
+	foreground, err := unix.IoctlGetInt(fd, unix.TIOCGPGRP)
+	if err != nil {
+		return
+	}
+	// A process running in the background can't take the terminal
+	if foreground != unix.Getpgrp() {
+		command.SysProcAttr = &syscall.SysProcAttr{}
+		return
+	}
+	command.SysProcAttr.Foreground = true
+	command.SysProcAttr.Ctty = fd
+}
+
+// restoreForeground gives the terminal back to wo once the function is done
+func restoreForeground(command *exec.Cmd) {
+	if !command.SysProcAttr.Foreground {
+		return
+	}
+	// Changing the foreground group from the background sends SIGTTOU
+	signal.Ignore(syscall.SIGTTOU)
+	defer signal.Reset(syscall.SIGTTOU)
+	err := unix.IoctlSetPointerInt(command.SysProcAttr.Ctty, unix.TIOCSPGRP, unix.Getpgrp())
+	if err != nil {
+		slog.Warn("the terminal can't be restored", slog.Any("error", err))
+	}
+}
+
+// signalProcess sends the signal to the process group of the shell when it
+// has its own group, to the shell only otherwise
+func signalProcess(command *exec.Cmd, sig syscall.Signal) error {
+	if command.Process == nil {
+		return nil
+	}
+	if command.SysProcAttr != nil && command.SysProcAttr.Setpgid {
+		return syscall.Kill(-command.Process.Pid, sig)
+	}
+	return command.Process.Signal(sig)
+}
@@ -0,0 +1,75 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	type scenario struct {
+		name     string
+		err      error
+		expected int
+	}
+	scenarios := []scenario{
+		{"No error", nil, 0},
+		{"A failure", exec.Command("/bin/sh", "-c", "exit 3").Run(), 3},
+		{"A failure after an interruption", fmt.Errorf("%w: %w", ErrInterrupted, exec.Command("/bin/sh", "-c", "exit 4").Run()), 4},
+		{"A kill", exec.Command("/bin/sh", "-c", "kill -TERM $$").Run(), 128 + int(syscall.SIGTERM)},
+		{"A timeout", fmt.Errorf("%w after 1s", ErrTimeout), TimeoutExitCode},
+		{"Another error", errors.New("an error occurred"), -1},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			assert.Equal(t, s.expected, ExitCode(s.err))
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	failure := exec.Command("/bin/sh", "-c", "exit 3").Run()
+	assert.True(t, isRetryable(failure))
+	assert.True(t, isRetryable(fmt.Errorf("%w after 1s", ErrTimeout)))
+	assert.False(t, isRetryable(fmt.Errorf("%w: %w", ErrInterrupted, failure)))
+	assert.False(t, isRetryable(exec.Command("/bin/sh", "-c", "exit 130").Run()))
+	assert.False(t, isRetryable(exec.Command("/bin/sh", "-c", "kill -TERM $$").Run()))
+	assert.False(t, isRetryable(errors.New("an error occurred")))
+	assert.False(t, isRetryable(nil))
+}
+
+func TestCommandWithTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := newCommand().command(ctx, "sh", t.TempDir(), &bytes.Buffer{}, &bytes.Buffer{}, "-c", "sleep 5; sleep 5")
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 4*time.Second)
+}
+
+func TestCommandForwardsSignals(t *testing.T) {
+	stdout := &bytes.Buffer{}
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		assert.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGTERM))
+	}()
+	err := newCommand().command(context.Background(), "sh", t.TempDir(), stdout, &bytes.Buffer{}, "-c", `trap "echo terminated; exit 7" TERM; sleep 5 & wait`)
+	assert.ErrorIs(t, err, ErrInterrupted)
+	assert.Equal(t, 7, ExitCode(err))
+	assert.Equal(t, "terminated\n", stdout.String())
+}
+
+func TestCommandInterruptedFromTheTerminal(t *testing.T) {
+	for _, script := range []string{"kill -TERM $$", "exit 130"} {
+		err := newCommand().command(context.Background(), "sh", t.TempDir(), &bytes.Buffer{}, &bytes.Buffer{}, "-c", script)
+		assert.ErrorIs(t, err, ErrInterrupted)
+	}
+}
@@ -0,0 +1,44 @@
+package workspace
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"slices"
+
+	"github.com/antham/wo/internal/task"
+)
+
+// listTaskFunctions lists the tasks of the task runners enabled in the
+// workspace as functions, they are read on each call so they follow the
+// changes of the project, a task whose name is already used is ignored
+func listTaskFunctions(path string, sources []string, functions []Function) []Function {
+	taskFunctions := []Function{}
+	for _, source := range sources {
+		tasks, err := task.Find(source, path)
+		if errors.Is(err, os.ErrNotExist) {
+			// The task file may not exist yet, the functions of the
+			// workspace must remain usable and the output clean as it
+			// is used by the completion
+			slog.Debug("the tasks can't be listed", "source", source, "error", err)
+			continue
+		}
+		if err != nil {
+			slog.Warn("the tasks can't be listed", "source", source, "error", err)
+			continue
+		}
+		for _, t := range tasks {
+			used := func(f Function) bool { return f.Name == t.Name }
+			if slices.ContainsFunc(functions, used) || slices.ContainsFunc(taskFunctions, used) {
+				continue
+			}
+			taskFunctions = append(taskFunctions, Function{
+				Name:        t.Name,
+				Description: t.Description,
+				Source:      source,
+				command:     t.Command,
+			})
+		}
+	}
+	return taskFunctions
+}
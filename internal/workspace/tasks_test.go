@@ -0,0 +1,97 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetWorkspaceWithTasks(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Create("api", project.getPath(t), ""))
+	assert.NoError(t, os.WriteFile(filepath.Join(config.getPath(t), "workspaces/api/functions/functions.bash"), []byte("# Run the tests\ntest() {\n  go test ./...\n}"), 0o666))
+	assert.NoError(t, os.WriteFile(filepath.Join(project.getPath(t), "Makefile"), []byte("# Build the binary\nbuild:\n\tgo build .\n\ntest:\n\tgo test ./...\n"), 0o666))
+	assert.NoError(t, os.WriteFile(filepath.Join(project.getPath(t), "package.json"), []byte(`{"scripts": {"build": "tsc", "lint": "eslint ."}}`), 0o666))
+
+	ws, err := w.Get("api")
+	assert.NoError(t, err)
+	assert.Equal(t, []Function{{Name: "test", Description: "Run the tests"}}, ws.Functions.Functions)
+
+	assert.NoError(t, w.SetConfig("api", map[string]any{"tasks": "makefile,npm,just"}))
+	ws, err = w.Get("api")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"makefile", "npm", "just"}, ws.Tasks)
+	assert.Equal(
+		t,
+		[]Function{
+			{Name: "build", Description: "Build the binary", Source: "makefile", command: []string{"make", "build"}},
+			{Name: "lint", Description: "eslint .", Source: "npm", command: []string{"npm", "run", "lint", "--"}},
+			{Name: "test", Description: "Run the tests"},
+		},
+		ws.Functions.Functions,
+	)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(project.getPath(t), "Makefile"), []byte("# Build the binary\nbuild:\n\tgo build .\n\n# Deploy the binary\ndeploy: build\n\tscp wo server:\n"), 0o666))
+	ws, err = w.Get("api")
+	assert.NoError(t, err)
+	names := []string{}
+	for _, f := range ws.Functions.Functions {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"build", "deploy", "lint", "test"}, names)
+}
+
+func TestRunFunctionWithTasks(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	type scenario struct {
+		name  string
+		shell string
+		setup func(*testing.T, *MockCommander)
+	}
+	scenarios := []scenario{
+		{
+			"Run a makefile target with a bash shell",
+			"/bin/bash",
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, os.Stderr, "-c", fmt.Sprintf("export WO_NAME=test && export WO_ENV=prod && source %s/workspaces/test/envs/prod.bash && source %s/workspaces/test/functions/functions.bash && make build VERSION=1.0.0 'NAME=it'\\''s me'", config.getPath(t), config.getPath(t))).Return(nil)
+			},
+		},
+		{
+			"Run a makefile target with a fish shell",
+			"/bin/fish",
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "fish", project.getPath(t), os.Stdout, os.Stderr, "-C", "set -x -g WO_NAME test", "-C", "set -x -g WO_ENV prod", "-C", fmt.Sprintf("source %s/workspaces/test/envs/prod.fish", config.getPath(t)), "-C", fmt.Sprintf("source %s/workspaces/test/functions/functions.fish", config.getPath(t)), "-c", `make build VERSION=1.0.0 'NAME=it\'s me'`).Return(nil)
+			},
+		},
+		{
+			"Run a makefile target with a nu shell",
+			"/bin/nu",
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "nu", project.getPath(t), os.Stdout, os.Stderr, "-c", fmt.Sprintf(`$env.WO_NAME = "test"; $env.WO_ENV = "prod"; source %s/workspaces/test/envs/prod.nu; source %s/workspaces/test/functions/functions.nu; ^"make" "build" "VERSION=1.0.0" "NAME=it's me"`, config.getPath(t), config.getPath(t))).Return(nil)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.RemoveAll(config.getPath(t))
+			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath(s.shell), WithConfigPath(config.getPath(t)))
+			assert.NoError(t, err)
+			assert.NoError(t, w.Create("test", project.getPath(t), ""))
+			assert.NoError(t, w.CreateEnv("test", "prod"))
+			assert.NoError(t, os.WriteFile(filepath.Join(project.getPath(t), "Makefile"), []byte("build:\n\tgo build .\n"), 0o666))
+			assert.NoError(t, w.SetConfig("test", map[string]any{"tasks": []string{"makefile"}}))
+			exec := NewMockCommander(t)
+			w.exec = exec
+			s.setup(t, exec)
+			assert.NoError(t, w.RunFunction("test", "prod", []string{"build", "VERSION=1.0.0", "NAME=it's me"}))
+		})
+	}
+}
@@ -2,51 +2,119 @@ package workspace
 
 import (
 	"cmp"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"maps"
 	"os"
 	"os/exec"
-	"os/user"
+	"os/signal"
 	"path"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"sort"
 	"strings"
+	"syscall"
+	"time"
 
+	"github.com/antham/wo/internal/logger"
 	"github.com/antham/wo/internal/shell"
+	"github.com/antham/wo/internal/task"
 	"github.com/spf13/viper"
 )
 
 const (
-	defaultConfigDir  = ".config/wo"
+	appDir            = "wo"
 	envVariablePrefix = "WO"
 	defaultEnv        = "default"
+	varsConfigKey     = "vars"
+	tasksConfigKey    = "tasks"
 )
 
 const (
 	bash = "bash"
+	dash = "dash"
 	fish = "fish"
+	ksh  = "ksh"
+	nu   = "nu"
 	sh   = "sh"
 	zsh  = "zsh"
 )
 
+var requiredConfigKeys = []string{"app", "path"}
+
 type Workspace struct {
 	Name      string
 	Functions Functions
 	Envs      []Env
 	Config    map[string]string
+	Tags      []string
+	Tasks     []string
+	Vars      map[string]string
 	dir       string
 }
 
+func (w Workspace) hasEnv(env string) bool {
+	return slices.ContainsFunc(w.Envs, func(e Env) bool {
+		return e.Name == env
+	})
+}
+
 type Functions struct {
 	file      string
 	Functions []Function
 }
 
 type Function struct {
-	Name        string
-	Description string
+	Name          string
+	Description   string
+	DefaultEnv    string
+	Envs          []string
+	Needs         []string
+	SensitiveArgs []int
+	// Source is the task runner defining the function, it is empty for the
+	// functions of the functions file
+	Source  string
+	command []string
+}
+
+func (f Functions) find(name string) (Function, bool) {
+	index := slices.IndexFunc(f.Functions, func(f Function) bool {
+		return f.Name == name
+	})
+	if index == -1 {
+		return Function{}, false
+	}
+	return f.Functions[index], true
+}
+
+func (f Function) resolveEnv(env string, fallback string) string {
+	switch {
+	case env != "":
+		return env
+	case f.DefaultEnv != "":
+		return f.DefaultEnv
+	}
+	return fallback
+}
+
+func (f Function) allowsEnv(env string) bool {
+	return len(f.Envs) == 0 || slices.Contains(f.Envs, env)
+}
+
+// redactArgs hides the arguments flagged as sensitive to log or record them
+func (f Function) redactArgs(args []string) []string {
+	redacted := []string{}
+	for i, arg := range args {
+		if slices.Contains(f.SensitiveArgs, shell.AllArgs) || slices.Contains(f.SensitiveArgs, i+1) {
+			arg = logger.Redacted
+		}
+		redacted = append(redacted, arg)
+	}
+	return redacted
 }
 
 type Env struct {
@@ -54,28 +122,60 @@ type Env struct {
 	file string
 }
 
+type RunOptions struct {
+	Stdout io.Writer
+	Stderr io.Writer
+	// Context stops the function when it is done
+	Context context.Context
+	// Timeout stops an attempt running for longer, 0 means no timeout
+	Timeout time.Duration
+	// Retries is the number of times a failing function is run again
+	Retries    int
+	RetryDelay time.Duration
+	// NoDeps skips the functions needed by the function
+	NoDeps bool
+}
+
 type WorkspaceManager struct {
 	editor    string
 	shellBin  string
 	shell     string
 	configDir string
-	exec      Commander
+	stateDir  string
+	cacheDir  string
+	// legacyConfigDir is the config directory of the previous versions, it's
+	// empty when the config directory is overridden
+	legacyConfigDir string
+	exec            Commander
 }
 
 func NewWorkspaceManager(options ...func(*WorkspaceManager)) (WorkspaceManager, error) {
 	w := WorkspaceManager{}
-	usr, err := user.Current()
+	home, err := os.UserHomeDir()
 	if err != nil {
 		return WorkspaceManager{}, err
 	}
-	w.configDir = fmt.Sprintf("%s/%s", usr.HomeDir, defaultConfigDir)
+	w.configDir = resolveXDGDir("XDG_CONFIG_HOME", home, ".config")
+	w.stateDir = resolveXDGDir("XDG_STATE_HOME", home, ".local/state")
+	w.cacheDir = resolveXDGDir("XDG_CACHE_HOME", home, ".cache")
+	xdgConfigDir := w.configDir
 	for _, o := range options {
 		o(&w)
 	}
+	if w.configDir == xdgConfigDir {
+		w.legacyConfigDir = filepath.Join(home, ".config", appDir)
+	}
+	editor, err := w.GetGlobalConfig(GlobalEditor)
+	if err != nil {
+		return WorkspaceManager{}, err
+	}
+	if editor != "" {
+		w.editor = editor
+	}
 	if w.editor == "" {
 		return WorkspaceManager{}, errors.New("no editor defined")
 	}
-	w.exec = newCommand(w.shellBin)
+	w.exec = newCommand()
 	return w, nil
 }
 
@@ -103,14 +203,57 @@ func WithConfigPath(path string) func(*WorkspaceManager) {
 	}
 }
 
-func (s WorkspaceManager) BuildAliases(prefix string) ([]string, error) {
+func WithOutput(stdout io.Writer, stderr io.Writer) func(*RunOptions) {
+	return func(r *RunOptions) {
+		r.Stdout = stdout
+		r.Stderr = stderr
+	}
+}
+
+func WithContext(ctx context.Context) func(*RunOptions) {
+	return func(r *RunOptions) {
+		r.Context = ctx
+	}
+}
+
+func WithTimeout(timeout time.Duration) func(*RunOptions) {
+	return func(r *RunOptions) {
+		r.Timeout = timeout
+	}
+}
+
+func WithRetry(retries int, delay time.Duration) func(*RunOptions) {
+	return func(r *RunOptions) {
+		r.Retries = retries
+		r.RetryDelay = delay
+	}
+}
+
+func WithoutDependencies() func(*RunOptions) {
+	return func(r *RunOptions) {
+		r.NoDeps = true
+	}
+}
+
+// BuildAliases creates the aliases moving to the workspaces for the given shell
+func (s WorkspaceManager) BuildAliases(app string, prefix string) ([]string, error) {
 	workspaces, err := s.List()
 	if err != nil {
 		return []string{}, err
 	}
 	aliases := []string{}
 	for _, w := range workspaces {
-		aliases = append(aliases, fmt.Sprintf(`alias %s%s="cd %s"`, prefix, w.Name, w.Config["path"]))
+		name := prefix + w.Name
+		switch {
+		case app == nu:
+			aliases = append(aliases, fmt.Sprintf(`alias %s = cd %s`, name, quote(app, w.Config["path"])))
+		// Aliases are not expanded in non interactive POSIX shells, functions
+		// are used instead when the name is a valid POSIX function name
+		case slices.Contains([]string{dash, ksh, sh}, app) && regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`).MatchString(name):
+			aliases = append(aliases, fmt.Sprintf(`%s() { cd %s; }`, name, quote(app, w.Config["path"])))
+		default:
+			aliases = append(aliases, fmt.Sprintf(`alias %s%s="cd %s"`, prefix, w.Name, w.Config["path"]))
+		}
 	}
 	return aliases, nil
 }
@@ -141,10 +284,22 @@ func (s WorkspaceManager) Get(name string) (Workspace, error) {
 	return s.getWorkspace(name)
 }
 
-func (s WorkspaceManager) Create(name string, path string) error {
+// Create creates a workspace whose functions are run with the app, the
+// current shell is used when the app is empty
+func (s WorkspaceManager) Create(name string, path string, app string) error {
 	if s.hasWorkspace(name) {
 		return fmt.Errorf(`workspace "%s" already exists`, name)
 	}
+	if app == "" {
+		app = s.shell
+	}
+	// The config is validated first so a workspace is never left half created
+	for key, value := range map[string]string{"app": app, "path": path} {
+		_, err := s.validateConfig(key, value)
+		if err != nil {
+			return err
+		}
+	}
 	err := s.createConfigFolder()
 	if err != nil {
 		return err
@@ -153,11 +308,11 @@ func (s WorkspaceManager) Create(name string, path string) error {
 	if err != nil {
 		return err
 	}
-	err = s.createFile(s.resolveFunctionFile(name))
+	err = s.createFile(s.resolveFunctionFile(name, app))
 	if err != nil {
 		return err
 	}
-	err = s.createFile(s.resolveEnvFile(name, defaultEnv))
+	err = s.createFile(s.resolveEnvFile(name, app, defaultEnv))
 	if err != nil {
 		return err
 	}
@@ -167,22 +322,22 @@ func (s WorkspaceManager) Create(name string, path string) error {
 	}
 	return s.SetConfig(
 		name,
-		map[string]string{
-			"app":  s.shell,
+		map[string]any{
+			"app":  app,
 			"path": path,
 		},
 	)
 }
 
 func (s WorkspaceManager) CreateEnv(name string, env string) error {
-	_, err := s.getWorkspace(name)
+	w, err := s.getWorkspace(name)
 	if err != nil {
 		return err
 	}
-	if s.hasEnv(name, env) {
+	if w.hasEnv(env) {
 		return fmt.Errorf(`env "%s" already exists`, env)
 	}
-	return s.createFile(s.resolveEnvFile(name, env))
+	return s.createFile(s.resolveEnvFile(name, w.Config["app"], env))
 }
 
 func (s WorkspaceManager) Edit(name string) error {
@@ -207,22 +362,167 @@ func (s WorkspaceManager) EditEnv(name string, env string) error {
 	return s.editFile(w.Envs[index].file)
 }
 
-func (s WorkspaceManager) RunFunction(name string, env string, functionAndArgs []string) error {
-	w, err := s.getWorkspace(name)
+func (s WorkspaceManager) RunFunction(name string, env string, functionAndArgs []string, options ...func(*RunOptions)) error {
+	runOptions := RunOptions{
+		Stdout:  os.Stdout,
+		Stderr:  os.Stderr,
+		Context: context.Background(),
+	}
+	for _, o := range options {
+		o(&runOptions)
+	}
+	w, function, env, err := s.resolveRun(name, env, functionAndArgs)
 	if err != nil {
 		return err
 	}
-	if !slices.ContainsFunc(w.Envs, func(e Env) bool {
-		return e.Name == env
-	}) {
-		return fmt.Errorf("the env `%s` does not exist", env)
+	slog.Debug(
+		"function to run",
+		slog.String("workspace", name),
+		slog.String("env", env),
+		slog.String("function", function.Name),
+		slog.Any("args", function.redactArgs(functionAndArgs[1:])),
+		slog.String("shell", w.Config["app"]),
+		slog.String("path", w.Config["path"]),
+	)
+	dependencies := []Function{}
+	if !runOptions.NoDeps {
+		dependencies, err = resolveDependencies(w, function, env)
+		if err != nil {
+			return err
+		}
+	}
+	start := time.Now()
+	err = s.runDependencies(w, env, dependencies, runOptions)
+	if err == nil {
+		err = s.runWithRetries(w, env, functionAndArgs, runOptions)
+	}
+	// A failure to record the run must not hide the result of the function
+	historyErr := s.recordHistory(newHistoryEntry(name, env, function, functionAndArgs[1:], start, err))
+	if historyErr != nil {
+		slog.Warn("the run can't be recorded in the history", "error", historyErr)
+	}
+	return err
+}
+
+// RunDependencies runs once the functions needed by the functions, so they can
+// then be run at the same time without their dependencies
+func (s WorkspaceManager) RunDependencies(name string, env string, functions []string, options ...func(*RunOptions)) error {
+	runOptions := RunOptions{
+		Stdout:  os.Stdout,
+		Stderr:  os.Stderr,
+		Context: context.Background(),
+	}
+	for _, o := range options {
+		o(&runOptions)
+	}
+	if runOptions.NoDeps {
+		return nil
+	}
+	// A function declaring its own default env runs its dependencies in it
+	run := map[string]bool{}
+	for _, f := range functions {
+		w, function, functionEnv, err := s.resolveRun(name, env, []string{f})
+		if err != nil {
+			return err
+		}
+		dependencies, err := resolveDependencies(w, function, functionEnv)
+		if err != nil {
+			return err
+		}
+		dependencies = slices.DeleteFunc(dependencies, func(d Function) bool {
+			return run[functionEnv+"/"+d.Name]
+		})
+		for _, d := range dependencies {
+			run[functionEnv+"/"+d.Name] = true
+		}
+		err = s.runDependencies(w, functionEnv, dependencies, runOptions)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runDependencies runs the dependencies in order without arguments, it stops
+// at the first failure
+func (s WorkspaceManager) runDependencies(w Workspace, env string, dependencies []Function, runOptions RunOptions) error {
+	for _, d := range dependencies {
+		if runOptions.Context.Err() != nil {
+			return runOptions.Context.Err()
+		}
+		fmt.Fprintf(runOptions.Stderr, "running the dependency `%s`\n", d.Name)
+		err := s.runWithRetries(w, env, []string{d.Name}, runOptions)
+		if err != nil {
+			return fmt.Errorf("%w `%s`: %w", ErrDependency, d.Name, err)
+		}
+	}
+	return nil
+}
+
+func (s WorkspaceManager) runWithRetries(w Workspace, env string, functionAndArgs []string, runOptions RunOptions) error {
+	for attempt := 0; ; attempt++ {
+		err := s.runAttempt(w, env, functionAndArgs, runOptions)
+		if !isRetryable(err) || attempt >= runOptions.Retries || runOptions.Context.Err() != nil {
+			return err
+		}
+		fmt.Fprintf(runOptions.Stderr, "%s, retrying in %s (%d/%d)\n", failureReason(err), runOptions.RetryDelay, attempt+1, runOptions.Retries)
+		if !sleep(runOptions.Context, runOptions.RetryDelay) {
+			return err
+		}
+	}
+}
+
+func (s WorkspaceManager) runAttempt(w Workspace, env string, functionAndArgs []string, runOptions RunOptions) error {
+	ctx := runOptions.Context
+	if runOptions.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, runOptions.Timeout)
+		defer cancel()
+	}
+	err := s.exec.command(ctx, w.Config["app"], w.Config["path"], runOptions.Stdout, runOptions.Stderr, s.appendLoadStatement(w, env, functionAndArgs)...)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", ErrTimeout, runOptions.Timeout)
+	}
+	// The function was stopped by the caller, another function run alongside
+	// it may have failed
+	if err != nil && errors.Is(ctx.Err(), context.Canceled) {
+		return fmt.Errorf("%w: %w", context.Canceled, err)
+	}
+	return err
+}
+
+// ResolveEnv returns the env the function is run in when no env is given
+func (s WorkspaceManager) ResolveEnv(name string, function string) (string, error) {
+	_, _, env, err := s.resolveRun(name, "", []string{function})
+	return env, err
+}
+
+// resolveRun returns the workspace, the function and the env used to run the
+// function
+func (s WorkspaceManager) resolveRun(name string, env string, functionAndArgs []string) (Workspace, Function, string, error) {
+	w, err := s.getWorkspace(name)
+	if err != nil {
+		return Workspace{}, Function{}, "", err
+	}
+	function, ok := w.Functions.find(functionAndArgs[0])
+	if !ok {
+		return Workspace{}, Function{}, "", fmt.Errorf("the function `%s` does not exist", functionAndArgs[0])
+	}
+	fallbackEnv, err := s.GetGlobalConfig(GlobalDefaultEnv)
+	if err != nil {
+		return Workspace{}, Function{}, "", err
+	}
+	if !w.hasEnv(fallbackEnv) {
+		fallbackEnv = defaultEnv
+	}
+	env = function.resolveEnv(env, fallbackEnv)
+	if !w.hasEnv(env) {
+		return Workspace{}, Function{}, "", fmt.Errorf("the env `%s` does not exist", env)
 	}
-	if !slices.ContainsFunc(w.Functions.Functions, func(f Function) bool {
-		return f.Name == functionAndArgs[0]
-	}) {
-		return fmt.Errorf("the function `%s` does not exist", functionAndArgs[0])
+	if !function.allowsEnv(env) {
+		return Workspace{}, Function{}, "", fmt.Errorf("the function `%s` can't be run in the env `%s`, allowed envs are: %s", function.Name, env, strings.Join(function.Envs, ", "))
 	}
-	return s.exec.command(w.Config["path"], s.appendLoadStatement(name, env, functionAndArgs)...)
+	return w, function, env, nil
 }
 
 func (s WorkspaceManager) Remove(name string) error {
@@ -233,59 +533,321 @@ func (s WorkspaceManager) Remove(name string) error {
 	return os.RemoveAll(w.dir)
 }
 
-func (s WorkspaceManager) SetConfig(name string, kv map[string]string) error {
+func (s WorkspaceManager) SetConfig(name string, kv map[string]any) error {
 	v := s.getViper(name)
 	err := v.ReadInConfig()
 	if err != nil {
 		return err
 	}
+	previousApp := v.GetString("app")
 	for key, value := range kv {
-		if !slices.Contains([]string{"path", "app"}, key) {
-			return fmt.Errorf(`"%s" is not a valid config key`, key)
+		value, err := s.validateConfig(key, value)
+		if err != nil {
+			return err
+		}
+		v.Set(key, value)
+	}
+	err = v.WriteConfig()
+	if err != nil {
+		return err
+	}
+	if previousApp != "" && previousApp != v.GetString("app") {
+		err = s.renameWorkspaceFiles(name, previousApp, v.GetString("app"))
+		if err != nil {
+			return err
+		}
+	}
+	return s.touchWorkspacesDir()
+}
+
+// renameWorkspaceFiles changes the extension of the functions and envs files
+// when the app of a workspace is changed, the content is left untouched
+func (s WorkspaceManager) renameWorkspaceFiles(name string, from string, to string) error {
+	err := os.Rename(s.resolveFunctionFile(name, from), s.resolveFunctionFile(name, to))
+	if err != nil {
+		return err
+	}
+	envs, err := s.listEnvs(name, from)
+	if err != nil {
+		return err
+	}
+	for _, e := range envs {
+		err := os.Rename(e.file, s.resolveEnvFile(name, to, e.Name))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s WorkspaceManager) AddTags(name string, tags []string) error {
+	w, err := s.getWorkspace(name)
+	if err != nil {
+		return err
+	}
+	newTags := slices.Clone(w.Tags)
+	for _, tag := range tags {
+		if !slices.Contains(newTags, tag) {
+			newTags = append(newTags, tag)
+		}
+	}
+	slices.Sort(newTags)
+	return s.SetConfig(name, map[string]any{"tags": newTags})
+}
+
+func (s WorkspaceManager) RemoveTags(name string, tags []string) error {
+	w, err := s.getWorkspace(name)
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		if !slices.Contains(w.Tags, tag) {
+			return fmt.Errorf(`the tag "%s" does not exist`, tag)
+		}
+	}
+	newTags := slices.DeleteFunc(slices.Clone(w.Tags), func(tag string) bool {
+		return slices.Contains(tags, tag)
+	})
+	return s.SetConfig(name, map[string]any{"tags": newTags})
+}
+
+func (s WorkspaceManager) GetConfig(name string, key string) (string, error) {
+	v := s.getViper(name)
+	err := v.ReadInConfig()
+	if err != nil {
+		return "", err
+	}
+	if !v.IsSet(key) {
+		return "", fmt.Errorf(`the config key "%s" is not defined`, key)
+	}
+	switch v.Get(key).(type) {
+	case []any:
+		return strings.Join(v.GetStringSlice(key), ","), nil
+	case map[string]any:
+		return "", fmt.Errorf(`the config key "%s" is a namespace, a key like "%s.port" must be provided`, key, key)
+	}
+	return v.GetString(key), nil
+}
+
+func (s WorkspaceManager) UnsetConfig(name string, key string) error {
+	// The config keys are case insensitive
+	key = strings.ToLower(key)
+	if slices.Contains(requiredConfigKeys, key) {
+		return fmt.Errorf(`the config key "%s" is required and can't be unset`, key)
+	}
+	v := s.getViper(name)
+	err := v.ReadInConfig()
+	if err != nil {
+		return err
+	}
+	if !v.IsSet(key) {
+		return fmt.Errorf(`the config key "%s" is not defined`, key)
+	}
+	settings := v.AllSettings()
+	deleteConfigKey(settings, strings.Split(key, "."))
+	nv := s.getViper(name)
+	for k, value := range settings {
+		nv.Set(k, value)
+	}
+	err = nv.WriteConfigAs(s.resolveConfigFile(name))
+	if err != nil {
+		return err
+	}
+	return s.touchWorkspacesDir()
+}
+
+// touchWorkspacesDir updates the modification time of the workspaces folder
+// when a config changes, the shells compare it to know when the aliases must
+// be refreshed
+func (s WorkspaceManager) touchWorkspacesDir() error {
+	now := time.Now()
+	return os.Chtimes(s.getWorkspacesDir(), now, now)
+}
+
+func (s WorkspaceManager) validateConfig(key string, value any) (any, error) {
+	switch key {
+	case "path", "app":
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf(`the value of "%s" must be a string`, key)
 		}
 		if key == "path" {
-			_, err := os.Stat(value)
+			_, err := os.Stat(str)
 			if os.IsNotExist(err) {
-				return fmt.Errorf(`path "%s" does not exist`, value)
+				return nil, fmt.Errorf(`path "%s" does not exist`, str)
+			}
+		}
+		if key == "app" && !slices.Contains(s.GetSupportedApps(), str) {
+			return nil, fmt.Errorf(`app "%s" is not supported`, str)
+		}
+		return str, nil
+	case varsConfigKey:
+		return nil, fmt.Errorf(`a variable name must be provided, e.g. "%s.port"`, varsConfigKey)
+	case "tags":
+		return parseConfigList(key, value)
+	case tasksConfigKey:
+		sources, err := parseConfigList(key, value)
+		if err != nil {
+			return nil, err
+		}
+		for _, source := range sources {
+			if !slices.Contains(task.Sources(), source) {
+				return nil, fmt.Errorf(`"%s" is not a supported source, it must be one of: %s`, source, strings.Join(task.Sources(), ", "))
 			}
 		}
-		if key == "app" {
-			if !slices.Contains(s.GetSupportedApps(), value) {
-				return fmt.Errorf(`app "%s" is not supported`, value)
+		return sources, nil
+	}
+	if variable, ok := strings.CutPrefix(key, varsConfigKey+"."); ok {
+		// The config keys are lower cased when stored, an upper cased name
+		// would not be kept as it was given
+		if !regexp.MustCompile(`^[a-z_][a-z0-9_]*$`).MatchString(variable) {
+			return nil, fmt.Errorf(`"%s" is not a valid variable name, it must comprise lower case letters, numbers and underscore and not start with a number`, variable)
+		}
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf(`the value of "%s" must be a string`, key)
+		}
+		return str, nil
+	}
+	return nil, fmt.Errorf(`"%s" is not a valid config key`, key)
+}
+
+// parseConfigList parses a comma separated list or returns the list as is
+func parseConfigList(key string, value any) ([]string, error) {
+	var values []string
+	switch v := value.(type) {
+	case string:
+		for _, value := range strings.Split(v, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
 			}
 		}
-		v.Set(key, value)
+	case []string:
+		values = v
+	default:
+		return nil, fmt.Errorf(`the value of "%s" must be a list`, key)
+	}
+	if values == nil {
+		values = []string{}
 	}
-	return v.WriteConfig()
+	return values, nil
 }
 
-func (s WorkspaceManager) GetConfig(name string, key string) (string, error) {
+func deleteConfigKey(settings map[string]any, path []string) {
+	if len(path) == 1 {
+		delete(settings, path[0])
+		return
+	}
+	child, ok := settings[path[0]].(map[string]any)
+	if !ok {
+		return
+	}
+	deleteConfigKey(child, path[1:])
+	if len(child) == 0 {
+		delete(settings, path[0])
+	}
+}
+
+func (s WorkspaceManager) getConfigList(name string, key string) ([]string, error) {
 	v := s.getViper(name)
 	err := v.ReadInConfig()
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return v.GetString(key), nil
+	values := v.GetStringSlice(key)
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values, nil
+}
+
+func (s WorkspaceManager) getConfigMap(name string, key string) (map[string]string, error) {
+	v := s.getViper(name)
+	err := v.ReadInConfig()
+	if err != nil {
+		return nil, err
+	}
+	values := v.GetStringMapString(key)
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values, nil
 }
 
 func (s WorkspaceManager) GetSupportedApps() []string {
-	return []string{fish, bash, zsh, sh}
+	return []string{fish, bash, zsh, sh, ksh, dash, nu}
 }
 
 func (s WorkspaceManager) GetConfigDir() string {
 	return s.configDir
 }
 
-func (s WorkspaceManager) CreateEnvVariableStatement(name string, value string) string {
-	switch s.shell {
-	case bash, sh, zsh:
-		return fmt.Sprintf("export %s=%s", name, value)
+func (s WorkspaceManager) GetStateDir() string {
+	return s.stateDir
+}
+
+func (s WorkspaceManager) GetCacheDir() string {
+	return s.cacheDir
+}
+
+// GetWorkspacesModTime returns the latest modification time of the workspaces
+// folder and of the config files, it changes every time a workspace is
+// created, removed or configured
+func (s WorkspaceManager) GetWorkspacesModTime() (time.Time, error) {
+	info, err := os.Stat(s.getWorkspacesDir())
+	if os.IsNotExist(err) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	modTime := info.ModTime()
+	entries, err := os.ReadDir(s.getWorkspacesDir())
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, e := range entries {
+		info, err := os.Stat(s.resolveConfigFile(e.Name()))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return modTime, nil
+}
+
+func (s WorkspaceManager) CreateEnvVariableStatement(app string, name string, value string) string {
+	switch app {
+	case bash, dash, ksh, sh, zsh:
+		return fmt.Sprintf("export %s=%s", name, quote(app, value))
 	case fish:
-		return fmt.Sprintf("set -x -g %s %s", name, value)
+		return fmt.Sprintf("set -x -g %s %s", name, quote(app, value))
+	case nu:
+		return fmt.Sprintf("$env.%s = %s", name, quote(app, value))
 	}
 	return ""
 }
 
+func quote(app string, value string) string {
+	// Nushell converts unquoted values to numbers, booleans, etc
+	if app == nu {
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+	}
+	if value != "" && regexp.MustCompile(`^[a-zA-Z0-9_@%+=:,./-]+$`).MatchString(value) {
+		return value
+	}
+	switch app {
+	case fish:
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
 func (s WorkspaceManager) Fix() error {
 	entries, err := os.ReadDir(s.getWorkspacesDir())
 	if os.IsNotExist(err) {
@@ -296,15 +858,19 @@ func (s WorkspaceManager) Fix() error {
 		return err
 	}
 	for _, e := range entries {
+		app, err := s.GetConfig(e.Name(), "app")
+		if err != nil {
+			app = s.shell
+		}
 		err = s.createWorkspaceFolder(e.Name())
 		if err != nil {
 			return err
 		}
-		err = s.createFile(s.resolveFunctionFile(e.Name()))
+		err = s.createFile(s.resolveFunctionFile(e.Name(), app))
 		if err != nil {
 			return err
 		}
-		err = s.createFile(s.resolveEnvFile(e.Name(), defaultEnv))
+		err = s.createFile(s.resolveEnvFile(e.Name(), app, defaultEnv))
 		if err != nil {
 			return err
 		}
@@ -312,23 +878,33 @@ func (s WorkspaceManager) Fix() error {
 	return nil
 }
 
-func (s WorkspaceManager) appendLoadStatement(name string, env string, functionAndArgs []string) []string {
+func (s WorkspaceManager) appendLoadStatement(w Workspace, env string, functionAndArgs []string) []string {
+	app := w.Config["app"]
+	functionAndArgs = w.resolveCommand(functionAndArgs)
 	data := []string{}
-	data = append(data, s.CreateEnvVariableStatement(fmt.Sprintf("%s_NAME", envVariablePrefix), name))
-	data = append(data, s.CreateEnvVariableStatement(fmt.Sprintf("%s_ENV", envVariablePrefix), env))
-	envFile := s.resolveEnvFile(name, env)
-	_, eerr := os.Stat(envFile)
-	if eerr == nil {
-		data = append(data, fmt.Sprintf("source %s", envFile))
-	}
-	data = append(data, fmt.Sprintf("source %s", s.resolveFunctionFile(name)))
+	for _, v := range s.resolveVariables(w, env) {
+		data = append(data, s.CreateEnvVariableStatement(app, v.Name, v.Value))
+	}
+	// The source builtin does not exist in POSIX shells
+	source := "source"
+	if slices.Contains([]string{dash, ksh, sh}, app) {
+		source = "."
+	}
+	for _, file := range s.resolveSourcedFiles(w, env) {
+		data = append(data, fmt.Sprintf("%s %s", source, file))
+	}
 	stmts := []string{}
-	switch s.shell {
-	case bash, sh, zsh:
+	switch app {
+	case bash, dash, ksh, sh, zsh:
 		if len(functionAndArgs) > 0 {
 			data = append(data, strings.Join(functionAndArgs, " "))
 		}
 		stmts = append(stmts, "-c", strings.Join(data, " && "))
+	case nu:
+		if len(functionAndArgs) > 0 {
+			data = append(data, strings.Join(functionAndArgs, " "))
+		}
+		stmts = append(stmts, "-c", strings.Join(data, "; "))
 	case fish:
 		for _, d := range data {
 			stmts = append(stmts, "-C", d)
@@ -340,8 +916,59 @@ func (s WorkspaceManager) appendLoadStatement(name string, env string, functionA
 	return stmts
 }
 
+// resolveCommand returns the command calling the function, a function defined
+// by a task runner is replaced with the command running the task, the
+// arguments are quoted so they reach the function as they were given
+func (w Workspace) resolveCommand(functionAndArgs []string) []string {
+	if len(functionAndArgs) == 0 {
+		return functionAndArgs
+	}
+	app := w.Config["app"]
+	command := []string{functionAndArgs[0]}
+	if function, ok := w.Functions.find(functionAndArgs[0]); ok && function.Source != "" {
+		command = []string{}
+		for _, arg := range function.command {
+			command = append(command, quote(app, arg))
+		}
+		// The nu builtins would shadow an external command of the same name
+		if app == nu {
+			command[0] = "^" + command[0]
+		}
+	}
+	for _, arg := range functionAndArgs[1:] {
+		command = append(command, quote(app, arg))
+	}
+	return command
+}
+
+// resolveVariables returns the environment variables exported before
+// running a function
+func (s WorkspaceManager) resolveVariables(w Workspace, env string) []EnvVariable {
+	variables := []EnvVariable{
+		{fmt.Sprintf("%s_NAME", envVariablePrefix), w.Name},
+		{fmt.Sprintf("%s_ENV", envVariablePrefix), env},
+	}
+	for _, key := range slices.Sorted(maps.Keys(w.Vars)) {
+		variables = append(variables, EnvVariable{fmt.Sprintf("%s_VAR_%s", envVariablePrefix, strings.ToUpper(key)), w.Vars[key]})
+	}
+	return variables
+}
+
+// resolveSourcedFiles returns the files sourced before running a function in
+// the order they are sourced, the env file is skipped when it does not exist
+func (s WorkspaceManager) resolveSourcedFiles(w Workspace, env string) []string {
+	app := w.Config["app"]
+	files := []string{}
+	envFile := s.resolveEnvFile(w.Name, app, env)
+	_, err := os.Stat(envFile)
+	if err == nil {
+		files = append(files, envFile)
+	}
+	return append(files, s.resolveFunctionFile(w.Name, app))
+}
+
 func (s WorkspaceManager) editFile(filepath string) error {
-	return s.exec.command("", "-c", fmt.Sprintf("%s %s", s.editor, filepath))
+	return s.exec.command(context.Background(), s.shellBin, "", os.Stdout, os.Stderr, "-c", fmt.Sprintf("%s %s", s.editor, filepath))
 }
 
 func (s WorkspaceManager) createFile(filepath string) error {
@@ -349,7 +976,7 @@ func (s WorkspaceManager) createFile(filepath string) error {
 	return err
 }
 
-func (s WorkspaceManager) listEnvs(name string) ([]Env, error) {
+func (s WorkspaceManager) listEnvs(name string, app string) ([]Env, error) {
 	envs := []Env{}
 	dir := s.getWorkspaceEnvsDir(name)
 	file, err := os.Open(dir)
@@ -362,7 +989,7 @@ func (s WorkspaceManager) listEnvs(name string) ([]Env, error) {
 	}
 	for _, f := range fs {
 		env := strings.TrimSuffix(f.Name(), filepath.Ext(f.Name()))
-		envs = append(envs, Env{Name: env, file: s.resolveEnvFile(name, env)})
+		envs = append(envs, Env{Name: env, file: s.resolveEnvFile(name, app, env)})
 	}
 	sort.Slice(envs, func(i, j int) bool {
 		return envs[i].Name < envs[j].Name
@@ -374,27 +1001,19 @@ func (s WorkspaceManager) resolveGitignoreFile() string {
 	return fmt.Sprintf("%s/.gitignore", s.GetConfigDir())
 }
 
-func (s WorkspaceManager) resolveFunctionFile(name string) string {
-	return fmt.Sprintf("%s/functions.%s", s.getWorkspaceFunctionsDir(name), s.getExtension())
+// resolveFunctionFile returns the functions file, its extension is the app of the workspace
+func (s WorkspaceManager) resolveFunctionFile(name string, app string) string {
+	return fmt.Sprintf("%s/functions.%s", s.getWorkspaceFunctionsDir(name), app)
 }
 
-func (s WorkspaceManager) resolveEnvFile(name string, env string) string {
-	return fmt.Sprintf("%s/%s.%s", s.getWorkspaceEnvsDir(name), env, s.getExtension())
+func (s WorkspaceManager) resolveEnvFile(name string, app string, env string) string {
+	return fmt.Sprintf("%s/%s.%s", s.getWorkspaceEnvsDir(name), env, app)
 }
 
 func (s WorkspaceManager) resolveConfigFile(name string) string {
 	return fmt.Sprintf("%s/config.toml", s.getWorkspaceDir(name))
 }
 
-func (s WorkspaceManager) getExtension() string {
-	for _, shell := range s.GetSupportedApps() {
-		if strings.Contains(s.shellBin, shell) {
-			return shell
-		}
-	}
-	return ""
-}
-
 func (s WorkspaceManager) createConfigFolder() error {
 	err := errors.Join(os.MkdirAll(s.configDir, 0o777), os.WriteFile(s.resolveGitignoreFile(), []byte("**/envs/**\n"), 0o666))
 	if err != nil {
@@ -412,8 +1031,9 @@ func (s WorkspaceManager) createConfigFolder() error {
 	if err != nil {
 		return err
 	}
-	if v.GetString("shell") != s.shell {
-		return fmt.Errorf(`the configured shell for the app "%s" is different from the one being used "%s"`, v.GetString("shell"), s.shell)
+	if !v.IsSet("shell") {
+		v.Set("shell", s.shell)
+		return v.WriteConfig()
 	}
 	return nil
 }
@@ -450,11 +1070,6 @@ func (s WorkspaceManager) getViper(name string) *viper.Viper {
 	return v
 }
 
-func (s WorkspaceManager) hasEnv(name string, env string) bool {
-	_, err := os.Stat(s.resolveEnvFile(name, env))
-	return !os.IsNotExist(err)
-}
-
 func (s WorkspaceManager) hasWorkspace(name string) bool {
 	_, err := os.Stat(s.getWorkspaceDir(name))
 	return !os.IsNotExist(err)
@@ -473,25 +1088,33 @@ func (s WorkspaceManager) getWorkspace(name string) (Workspace, error) {
 	if err != nil || path == "" {
 		return Workspace{}, errors.New("the config file of the workspace is corrupted")
 	}
-	if app != s.shell {
-		return Workspace{}, fmt.Errorf(`the "%s" app is not supported for this workspace, it works with "%s"`, app, s.shell)
+	tags, err := s.getConfigList(name, "tags")
+	if err != nil {
+		return Workspace{}, errors.New("the config file of the workspace is corrupted")
+	}
+	tasks, err := s.getConfigList(name, tasksConfigKey)
+	if err != nil {
+		return Workspace{}, errors.New("the config file of the workspace is corrupted")
+	}
+	vars, err := s.getConfigMap(name, varsConfigKey)
+	if err != nil {
+		return Workspace{}, errors.New("the config file of the workspace is corrupted")
 	}
-	_, err = os.ReadFile(s.resolveEnvFile(name, defaultEnv))
+	_, err = os.ReadFile(s.resolveEnvFile(name, app, defaultEnv))
 	if os.IsNotExist(err) {
 		return Workspace{}, errors.New("the default env file of the workspace is corrupted")
 	}
 	if err != nil {
 		return Workspace{}, err
 	}
-	content, err := os.ReadFile(s.resolveFunctionFile(name))
+	funcs, err := s.parseFunctions(app, s.resolveFunctionFile(name, app))
 	if os.IsNotExist(err) {
 		return Workspace{}, errors.New("the function file of the workspace is corrupted")
 	}
 	if err != nil {
 		return Workspace{}, err
 	}
-	funcs := shell.Parse(s.shell, content)
-	envs, err := s.listEnvs(name)
+	envs, err := s.listEnvs(name, app)
 	if err != nil {
 		return Workspace{}, err
 	}
@@ -499,18 +1122,23 @@ func (s WorkspaceManager) getWorkspace(name string) (Workspace, error) {
 	for _, f := range funcs {
 		functions = append(
 			functions, Function{
-				Name:        f.Name,
-				Description: f.Description,
+				Name:          f.Name,
+				Description:   f.Description,
+				DefaultEnv:    f.DefaultEnv,
+				Envs:          f.Envs,
+				Needs:         f.Needs,
+				SensitiveArgs: f.SensitiveArgs,
 			},
 		)
 	}
+	functions = append(functions, listTaskFunctions(path, tasks, functions)...)
 	slices.SortFunc(functions, func(a, b Function) int {
 		return cmp.Compare(a.Name, b.Name)
 	})
 	return Workspace{
 		Name: name,
 		Functions: Functions{
-			file:      s.resolveFunctionFile(name),
+			file:      s.resolveFunctionFile(name, app),
 			Functions: functions,
 		},
 		Envs: envs,
@@ -518,26 +1146,98 @@ func (s WorkspaceManager) getWorkspace(name string) (Workspace, error) {
 			"path": path,
 			"app":  app,
 		},
-		dir: s.getWorkspaceDir(name),
+		Tags:  tags,
+		Tasks: tasks,
+		Vars:  vars,
+		dir:   s.getWorkspaceDir(name),
 	}, nil
 }
 
-type command struct {
-	shellBin string
+type command struct{}
+
+func newCommand() *command {
+	return &command{}
 }
 
-func newCommand(shellBin string) *command {
-	return &command{
-		shellBin: shellBin,
+// command runs the shell with the arguments, the shell is resolved from the
+// PATH when it's not a path, the shell is terminated when the context is done
+// and the interrupt and terminate signals received by wo are forwarded to it
+func (c *command) command(ctx context.Context, shell string, path string, stdout io.Writer, stderr io.Writer, args ...string) error {
+	shellBin, err := exec.LookPath(shell)
+	if err != nil {
+		return fmt.Errorf(`the shell "%s" can't be found, it must be installed and available in the PATH`, shell)
+	}
+	command := exec.CommandContext(ctx, shellBin, args...)
+	command.Stdout = stdout
+	command.Stderr = stderr
+	command.Dir = path
+	// A function whose output is captured may run alongside other ones, it
+	// can't take the terminal nor read from it
+	interactive := stdout == io.Writer(os.Stdout)
+	if interactive {
+		command.Stdin = os.Stdin
+	}
+	setProcessGroup(command, interactive)
+	defer restoreForeground(command)
+	command.Cancel = func() error {
+		return signalProcess(command, syscall.SIGTERM)
+	}
+	command.WaitDelay = killDelay
+	// The arguments are not logged as they contain the env vars and the
+	// function arguments
+	slog.Debug("command to run", slog.String("shell", command.Path), slog.String("path", command.Dir))
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	err = command.Start()
+	if err != nil {
+		return err
 	}
+	done := make(chan struct{})
+	interrupted := make(chan bool, 1)
+	go func() {
+		received := false
+		defer func() { interrupted <- received }()
+		for {
+			select {
+			case sig := <-signals:
+				received = true
+				slog.Debug("signal forwarded", slog.String("signal", sig.String()))
+				err := signalProcess(command, sig.(syscall.Signal))
+				if err != nil {
+					slog.Warn("the signal can't be forwarded", slog.String("signal", sig.String()), slog.Any("error", err))
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	err = command.Wait()
+	close(done)
+	if (<-interrupted || isInterruptedExit(err)) && err != nil {
+		return fmt.Errorf("%w: %w", ErrInterrupted, err)
+	}
+	return err
 }
 
-func (c *command) command(path string, args ...string) error {
-	command := exec.Command(c.shellBin, args...)
-	command.Stdout = os.Stdout
-	command.Stdin = os.Stdin
-	command.Stderr = os.Stderr
+// start runs the shell in its own session, detached from the terminal and
+// from wo, the output is written in the log and the pid of the shell is
+// returned
+func (c *command) start(shell string, path string, log *os.File, args ...string) (int, error) {
+	shellBin, err := exec.LookPath(shell)
+	if err != nil {
+		return 0, fmt.Errorf(`the shell "%s" can't be found, it must be installed and available in the PATH`, shell)
+	}
+	command := exec.Command(shellBin, args...)
+	command.Stdout = log
+	command.Stderr = log
 	command.Dir = path
-	slog.With(slog.String("command", command.String())).With(slog.String("path", command.Dir)).Debug("command to run")
-	return command.Run()
+	command.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	slog.Debug("command to start", slog.String("shell", command.Path), slog.String("path", command.Dir))
+	err = command.Start()
+	if err != nil {
+		return 0, err
+	}
+	pid := command.Process.Pid
+	return pid, command.Process.Release()
 }
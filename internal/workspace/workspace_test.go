@@ -1,13 +1,34 @@
 package workspace
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"os/exec"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
+func TestMain(m *testing.M) {
+	// Keep the state and the cache of the tests away from the user directories
+	dir, err := os.MkdirTemp("/tmp", "wo-xdg")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("XDG_STATE_HOME", fmt.Sprintf("%s/state", dir))
+	os.Setenv("XDG_CACHE_HOME", fmt.Sprintf("%s/cache", dir))
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
 type config struct {
 	path string
 }
@@ -107,11 +128,11 @@ func TestList(t *testing.T) {
 		{
 			"Get all workspaces ordered alphabetically",
 			func(t *testing.T, w WorkspaceManager) {
-				assert.NoError(t, w.Create("api", project.getPath(t)))
+				assert.NoError(t, w.Create("api", project.getPath(t), ""))
 				assert.NoError(t, w.CreateEnv("api", "dev"))
-				assert.NoError(t, w.Create("db", project.getPath(t)))
+				assert.NoError(t, w.Create("db", project.getPath(t), ""))
 				assert.NoError(t, w.CreateEnv("db", "staging"))
-				assert.NoError(t, w.Create("front", project.getPath(t)))
+				assert.NoError(t, w.Create("front", project.getPath(t), ""))
 				assert.NoError(t, w.CreateEnv("front", "prod"))
 			},
 			func(t *testing.T, ws []Workspace, err error) {
@@ -157,7 +178,7 @@ func TestGet(t *testing.T) {
 		{
 			"Get all workspace",
 			func(t *testing.T, w WorkspaceManager) {
-				err := w.Create("front", project.getPath(t))
+				err := w.Create("front", project.getPath(t), "")
 				assert.NoError(t, err)
 				err = w.CreateEnv("front", "prod")
 				assert.NoError(t, err)
@@ -278,7 +299,7 @@ func TestCreate(t *testing.T) {
 		{
 			"Creating workspace twice fails",
 			func(t *testing.T, w WorkspaceManager) string {
-				assert.NoError(t, w.Create("test", project.getPath(t)))
+				assert.NoError(t, w.Create("test", project.getPath(t), ""))
 				return project.getPath(t)
 			},
 			func(t *testing.T, err error) {
@@ -286,16 +307,18 @@ func TestCreate(t *testing.T) {
 			},
 		},
 		{
-			"Creating workspace with 2 different shells",
+			"Creating workspaces from 2 different shells",
 			func(t *testing.T, w WorkspaceManager) string {
 				w1, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/zsh"), WithConfigPath(config.getPath(t)))
 				assert.NoError(t, err)
-				assert.NoError(t, w1.Create("test2", project.getPath(t)))
+				assert.NoError(t, w1.Create("test2", project.getPath(t), ""))
 				return project.getPath(t)
 			},
 			func(t *testing.T, err error) {
-				assert.Error(t, err)
-				t.Log(err)
+				assert.NoError(t, err)
+				path := config.getPath(t)
+				assert.FileExists(t, path+"/workspaces/test/functions/functions.bash")
+				assert.FileExists(t, path+"/workspaces/test2/functions/functions.zsh")
 			},
 		},
 	}
@@ -306,11 +329,30 @@ func TestCreate(t *testing.T) {
 			assert.NoError(t, err)
 			projectPath := s.setup(t, w)
 			assert.NoError(t, err)
-			s.test(t, w.Create("test", projectPath))
+			s.test(t, w.Create("test", projectPath, ""))
 		})
 	}
 }
 
+func TestCreateWithApp(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/tcsh"), WithConfigPath(config.getPath(t)))
+	assert.NoError(t, err)
+
+	// Nothing is created when the config is not valid
+	assert.EqualError(t, w.Create("api", project.getPath(t), ""), `app "tcsh" is not supported`)
+	assert.EqualError(t, w.Create("api", "/tmp/tmp/temp", "fish"), `path "/tmp/tmp/temp" does not exist`)
+	assert.NoDirExists(t, config.getPath(t)+"/workspaces/api")
+
+	assert.NoError(t, w.Create("api", project.getPath(t), "fish"))
+	assert.FileExists(t, config.getPath(t)+"/workspaces/api/functions/functions.fish")
+	assert.FileExists(t, config.getPath(t)+"/workspaces/api/envs/default.fish")
+	app, err := w.GetConfig("api", "app")
+	assert.NoError(t, err)
+	assert.Equal(t, "fish", app)
+}
+
 func TestCreateEnv(t *testing.T) {
 	config := &config{}
 	project := &project{}
@@ -353,7 +395,7 @@ func TestCreateEnv(t *testing.T) {
 			os.RemoveAll(config.getPath(t))
 			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
 			assert.NoError(t, err)
-			assert.NoError(t, w.Create("test", project.getPath(t)))
+			assert.NoError(t, w.Create("test", project.getPath(t), ""))
 			s.setup(t, w)
 			s.test(t, w.CreateEnv("test", "prod"))
 		})
@@ -372,8 +414,8 @@ func TestEdit(t *testing.T) {
 		{
 			"Edit workspace",
 			func(t *testing.T, w WorkspaceManager, exec *MockCommander) {
-				exec.On("command", "", "-c", fmt.Sprintf("emacs %s/workspaces/test/functions/functions.bash", config.getPath(t))).Return(nil)
-				err := w.Create("test", project.getPath(t))
+				exec.On("command", mock.Anything, "/bin/bash", "", os.Stdout, os.Stderr, "-c", fmt.Sprintf("emacs %s/workspaces/test/functions/functions.bash", config.getPath(t))).Return(nil)
+				err := w.Create("test", project.getPath(t), "")
 				assert.NoError(t, err)
 			},
 			func(t *testing.T) {
@@ -410,14 +452,14 @@ func TestEditEnv(t *testing.T) {
 			"Edit default workspace",
 			"default",
 			func(t *testing.T, exec *MockCommander) {
-				exec.On("command", "", "-c", fmt.Sprintf("emacs %s/workspaces/test/envs/default.bash", config.getPath(t))).Return(nil)
+				exec.On("command", mock.Anything, "/bin/bash", "", os.Stdout, os.Stderr, "-c", fmt.Sprintf("emacs %s/workspaces/test/envs/default.bash", config.getPath(t))).Return(nil)
 			},
 		},
 		{
 			"Edit prod workspace",
 			"prod",
 			func(t *testing.T, exec *MockCommander) {
-				exec.On("command", "", "-c", fmt.Sprintf("emacs %s/workspaces/test/envs/prod.bash", config.getPath(t))).Return(nil)
+				exec.On("command", mock.Anything, "/bin/bash", "", os.Stdout, os.Stderr, "-c", fmt.Sprintf("emacs %s/workspaces/test/envs/prod.bash", config.getPath(t))).Return(nil)
 			},
 		},
 	}
@@ -426,7 +468,7 @@ func TestEditEnv(t *testing.T) {
 			os.RemoveAll(config.getPath(t))
 			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
 			assert.NoError(t, err)
-			err = w.Create("test", project.getPath(t))
+			err = w.Create("test", project.getPath(t), "")
 			assert.NoError(t, err)
 			err = w.CreateEnv("test", "prod")
 			assert.NoError(t, err)
@@ -462,7 +504,7 @@ run-db() {
 }
 `), 0o777))
 
-				exec.On("command", project.getPath(t), "-c", fmt.Sprintf("export WO_NAME=test && export WO_ENV=default && source %s/workspaces/test/envs/default.bash && source %s/workspaces/test/functions/functions.bash && run-db", config.getPath(t), config.getPath(t))).Return(nil)
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, os.Stderr, "-c", fmt.Sprintf("export WO_NAME=test && export WO_ENV=default && source %s/workspaces/test/envs/default.bash && source %s/workspaces/test/functions/functions.bash && run-db", config.getPath(t), config.getPath(t))).Return(nil)
 			},
 		},
 		{
@@ -477,7 +519,7 @@ function run-db
 
 end
 `), 0o777))
-				exec.On("command", project.getPath(t), "-C", "set -x -g WO_NAME test", "-C", "set -x -g WO_ENV default", "-C", fmt.Sprintf("source %s/workspaces/test/envs/default.fish", config.getPath(t)), "-C", fmt.Sprintf("source %s/workspaces/test/functions/functions.fish", config.getPath(t)), "-c", "run-db").Return(nil)
+				exec.On("command", mock.Anything, "fish", project.getPath(t), os.Stdout, os.Stderr, "-C", "set -x -g WO_NAME test", "-C", "set -x -g WO_ENV default", "-C", fmt.Sprintf("source %s/workspaces/test/envs/default.fish", config.getPath(t)), "-C", fmt.Sprintf("source %s/workspaces/test/functions/functions.fish", config.getPath(t)), "-c", "run-db").Return(nil)
 			},
 		},
 		{
@@ -493,7 +535,7 @@ run-db() {
 }
 `), 0o777))
 
-				exec.On("command", project.getPath(t), "-c", fmt.Sprintf("export WO_NAME=test && export WO_ENV=prod && source %s/workspaces/test/envs/prod.bash && source %s/workspaces/test/functions/functions.bash && run-db watch", config.getPath(t), config.getPath(t))).Return(nil)
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, os.Stderr, "-c", fmt.Sprintf("export WO_NAME=test && export WO_ENV=prod && source %s/workspaces/test/envs/prod.bash && source %s/workspaces/test/functions/functions.bash && run-db watch", config.getPath(t), config.getPath(t))).Return(nil)
 			},
 		},
 		{
@@ -507,7 +549,37 @@ run-db() {
 function run-db
 end
 `), 0o777))
-				exec.On("command", project.getPath(t), "-C", "set -x -g WO_NAME test", "-C", "set -x -g WO_ENV prod", "-C", fmt.Sprintf("source %s/workspaces/test/envs/prod.fish", config.getPath(t)), "-C", fmt.Sprintf("source %s/workspaces/test/functions/functions.fish", config.getPath(t)), "-c", "run-db watch").Return(nil)
+				exec.On("command", mock.Anything, "fish", project.getPath(t), os.Stdout, os.Stderr, "-C", "set -x -g WO_NAME test", "-C", "set -x -g WO_ENV prod", "-C", fmt.Sprintf("source %s/workspaces/test/envs/prod.fish", config.getPath(t)), "-C", fmt.Sprintf("source %s/workspaces/test/functions/functions.fish", config.getPath(t)), "-c", "run-db watch").Return(nil)
+			},
+		},
+		{
+			"Run a function with arguments to quote and a bash shell",
+			[]string{"run-db", "hello world", "$HOME; ls"},
+			"prod",
+			"/bin/bash",
+			func(t *testing.T, exec *MockCommander) {
+				functionPath := config.getPath(t) + "/workspaces/test/functions/functions.bash"
+				assert.NoError(t, os.WriteFile(functionPath, []byte(`
+run-db() {
+
+}
+`), 0o777))
+
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, os.Stderr, "-c", fmt.Sprintf("export WO_NAME=test && export WO_ENV=prod && source %s/workspaces/test/envs/prod.bash && source %s/workspaces/test/functions/functions.bash && run-db 'hello world' '$HOME; ls'", config.getPath(t), config.getPath(t))).Return(nil)
+			},
+		},
+		{
+			"Run a function with arguments to quote and a fish shell",
+			[]string{"run-db", "hello world", "$HOME; ls"},
+			"prod",
+			"/bin/fish",
+			func(t *testing.T, exec *MockCommander) {
+				functionPath := config.getPath(t) + "/workspaces/test/functions/functions.fish"
+				assert.NoError(t, os.WriteFile(functionPath, []byte(`
+function run-db
+end
+`), 0o777))
+				exec.On("command", mock.Anything, "fish", project.getPath(t), os.Stdout, os.Stderr, "-C", "set -x -g WO_NAME test", "-C", "set -x -g WO_ENV prod", "-C", fmt.Sprintf("source %s/workspaces/test/envs/prod.fish", config.getPath(t)), "-C", fmt.Sprintf("source %s/workspaces/test/functions/functions.fish", config.getPath(t)), "-c", "run-db 'hello world' '$HOME; ls'").Return(nil)
 			},
 		},
 	}
@@ -516,7 +588,7 @@ end
 			os.RemoveAll(config.getPath(t))
 			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath(s.shell), WithConfigPath(config.getPath(t)))
 			assert.NoError(t, err)
-			err = w.Create("test", project.getPath(t))
+			err = w.Create("test", project.getPath(t), "")
 			assert.NoError(t, err)
 			err = w.CreateEnv("test", "prod")
 			assert.NoError(t, err)
@@ -528,97 +600,185 @@ end
 	}
 }
 
-func TestRemove(t *testing.T) {
+func TestRunFunctionWithEnvAnnotations(t *testing.T) {
 	config := &config{}
 	project := &project{}
 	type scenario struct {
-		name      string
-		workspace string
-		test      func(*testing.T, error)
+		name  string
+		env   string
+		setup func(*testing.T, *MockCommander)
+		test  func(*testing.T, error)
 	}
 	scenarios := []scenario{
 		{
-			"Remove an unexisting workspace",
-			"whatever",
-			func(t *testing.T, e error) {
-				assert.Error(t, e)
+			"Run a function without env uses the default env of the function",
+			"",
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, os.Stderr, "-c", fmt.Sprintf("export WO_NAME=test && export WO_ENV=staging && source %s/workspaces/test/envs/staging.bash && source %s/workspaces/test/functions/functions.bash && deploy", config.getPath(t), config.getPath(t))).Return(nil)
+			},
+			func(t *testing.T, err error) {
+				assert.NoError(t, err)
 			},
 		},
 		{
-			"Remove a workspace",
-			"test",
-			func(t *testing.T, e error) {
-				assert.NoError(t, e)
-				path := config.getPath(t)
-				_, err := os.Stat(path + "/workspaces/test/envs/prod.bash")
-				assert.True(t, os.IsNotExist(err))
-				_, err = os.Stat(path + "/workspaces/test/envs/dev.bash")
-				assert.True(t, os.IsNotExist(err))
-				_, err = os.Stat(path + "/workspaces/test/functions/functions.bash")
-				assert.True(t, os.IsNotExist(err))
-				_, err = os.Stat(path + "/workspaces/test/config.toml")
-				assert.True(t, os.IsNotExist(err))
-
-				_, err = os.Stat(path + "/workspaces/front/functions/functions.bash")
-				assert.NoError(t, err)
-				_, err = os.Stat(path + "/workspaces/front/envs/dev.bash")
+			"Run a function with an allowed env",
+			"prod",
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, os.Stderr, "-c", fmt.Sprintf("export WO_NAME=test && export WO_ENV=prod && source %s/workspaces/test/envs/prod.bash && source %s/workspaces/test/functions/functions.bash && deploy", config.getPath(t), config.getPath(t))).Return(nil)
+			},
+			func(t *testing.T, err error) {
 				assert.NoError(t, err)
 			},
 		},
+		{
+			"Run a function with an env not allowed",
+			"default",
+			func(t *testing.T, exec *MockCommander) {},
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, "the function `deploy` can't be run in the env `default`, allowed envs are: staging, prod")
+			},
+		},
+		{
+			"Run a function with an unexisting env",
+			"whatever",
+			func(t *testing.T, exec *MockCommander) {},
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, "the env `whatever` does not exist")
+			},
+		},
 	}
 	for _, s := range scenarios {
 		t.Run(s.name, func(t *testing.T) {
 			os.RemoveAll(config.getPath(t))
 			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
 			assert.NoError(t, err)
-			err = w.Create("test", project.getPath(t))
-			assert.NoError(t, err)
-			err = w.CreateEnv("test", "prod")
-			assert.NoError(t, err)
-			err = w.CreateEnv("test", "dev")
-			assert.NoError(t, err)
-			err = w.Create("front", project.getPath(t))
-			assert.NoError(t, err)
-			err = w.CreateEnv("front", "dev")
-			assert.NoError(t, err)
-			s.test(t, w.Remove(s.workspace))
+			assert.NoError(t, w.Create("test", project.getPath(t), ""))
+			assert.NoError(t, w.CreateEnv("test", "staging"))
+			assert.NoError(t, w.CreateEnv("test", "prod"))
+			functionPath := config.getPath(t) + "/workspaces/test/functions/functions.bash"
+			assert.NoError(t, os.WriteFile(functionPath, []byte(`
+# Deploy the app
+# @default-env staging
+# @envs staging prod
+deploy() {
+
+}
+`), 0o777))
+			exec := NewMockCommander(t)
+			w.exec = exec
+			s.setup(t, exec)
+			s.test(t, w.RunFunction("test", s.env, []string{"deploy"}))
 		})
 	}
 }
 
-func TestFix(t *testing.T) {
+func TestRunFunctionWithSensitiveArgs(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	buf := &bytes.Buffer{}
+	defaultLogger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(defaultLogger) })
+
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Create("test", project.getPath(t), ""))
+	assert.NoError(t, os.WriteFile(config.getPath(t)+"/workspaces/test/functions/functions.bash", []byte(`
+# Log in
+# @sensitive-args 2
+login() {
+
+}
+`), 0o777))
+	exec := NewMockCommander(t)
+	exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, os.Stderr, "-c", fmt.Sprintf("export WO_NAME=test && export WO_ENV=default && source %s/workspaces/test/envs/default.bash && source %s/workspaces/test/functions/functions.bash && login john hunter2", config.getPath(t), config.getPath(t))).Return(nil)
+	w.exec = exec
+	assert.NoError(t, w.RunFunction("test", "", []string{"login", "john", "hunter2"}))
+	assert.Contains(t, buf.String(), `msg="function to run" workspace=test env=default function=login args="[john [REDACTED]]" shell=bash`)
+	assert.NotContains(t, buf.String(), "hunter2")
+}
+
+func TestRunFunctionWithRetriesAndTimeout(t *testing.T) {
 	config := &config{}
 	project := &project{}
+	failure := exec.Command("/bin/sh", "-c", "exit 3").Run()
+	interrupted := exec.Command("/bin/sh", "-c", "exit 130").Run()
+	canceledContext, cancel := context.WithCancel(context.Background())
+	cancel()
+	waitTimeout := func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}
 	type scenario struct {
-		name  string
-		setup func(*testing.T, WorkspaceManager)
-		test  func(*testing.T, error)
+		name    string
+		options []func(*RunOptions)
+		setup   func(*testing.T, *MockCommander)
+		test    func(*testing.T, string, error)
 	}
 	scenarios := []scenario{
 		{
-			"Fix a failing workspace",
-			func(*testing.T, WorkspaceManager) {
-				path := config.getPath(t)
-				err := os.RemoveAll(path + "/workspaces/test/envs")
-				assert.NoError(t, err)
-				err = os.Remove(path + "/workspaces/test/functions/functions.bash")
-				assert.NoError(t, err)
-				err = os.RemoveAll(path + "/workspaces/front/envs")
-				assert.NoError(t, err)
-				err = os.Remove(path + "/workspaces/front/functions/functions.bash")
-				assert.NoError(t, err)
+			"The function succeeds after a retry",
+			[]func(*RunOptions){WithRetry(2, time.Millisecond)},
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, mock.Anything, "-c", mock.Anything).Return(failure).Once()
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, mock.Anything, "-c", mock.Anything).Return(nil).Once()
 			},
-			func(t *testing.T, e error) {
-				assert.NoError(t, e)
-				path := config.getPath(t)
-				_, err := os.Stat(path + "/workspaces/test/functions/functions.bash")
-				assert.NoError(t, err)
-				_, err = os.Stat(path + "/workspaces/test/envs/default.bash")
-				assert.NoError(t, err)
-				_, err = os.Stat(path + "/workspaces/front/functions/functions.bash")
-				assert.NoError(t, err)
-				_, err = os.Stat(path + "/workspaces/front/envs/default.bash")
+			func(t *testing.T, stderr string, err error) {
 				assert.NoError(t, err)
+				assert.Equal(t, "the function failed with exit code 3, retrying in 1ms (1/2)\n", stderr)
+			},
+		},
+		{
+			"The function fails after all the retries",
+			[]func(*RunOptions){WithRetry(1, 0)},
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, mock.Anything, "-c", mock.Anything).Return(failure).Twice()
+			},
+			func(t *testing.T, stderr string, err error) {
+				assert.Equal(t, 3, ExitCode(err))
+			},
+		},
+		{
+			"The function is not run again when it can't be run",
+			[]func(*RunOptions){WithRetry(2, 0)},
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, mock.Anything, "-c", mock.Anything).Return(errors.New("an error occurred")).Once()
+			},
+			func(t *testing.T, stderr string, err error) {
+				assert.EqualError(t, err, "an error occurred")
+			},
+		},
+		{
+			"The function is not run again when it is interrupted from the terminal",
+			[]func(*RunOptions){WithRetry(2, 0)},
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, mock.Anything, "-c", mock.Anything).Return(interrupted).Once()
+			},
+			func(t *testing.T, stderr string, err error) {
+				assert.Equal(t, 130, ExitCode(err))
+				assert.Empty(t, stderr)
+			},
+		},
+		{
+			"The function is not run again once its context is done",
+			[]func(*RunOptions){WithContext(canceledContext), WithRetry(2, time.Hour)},
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, mock.Anything, "-c", mock.Anything).Return(failure).Once()
+			},
+			func(t *testing.T, stderr string, err error) {
+				assert.Equal(t, 3, ExitCode(err))
+				assert.Empty(t, stderr)
+			},
+		},
+		{
+			"The function times out",
+			[]func(*RunOptions){WithTimeout(10 * time.Millisecond), WithRetry(1, 0)},
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, mock.Anything, "-c", mock.Anything).Run(waitTimeout).Return(failure).Twice()
+			},
+			func(t *testing.T, stderr string, err error) {
+				assert.ErrorIs(t, err, ErrTimeout)
+				assert.EqualError(t, err, "the function has timed out after 10ms")
+				assert.Equal(t, "the function has timed out after 10ms, retrying in 0s (1/1)\n", stderr)
 			},
 		},
 	}
@@ -627,75 +787,66 @@ func TestFix(t *testing.T) {
 			os.RemoveAll(config.getPath(t))
 			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
 			assert.NoError(t, err)
-			err = w.Create("test", project.getPath(t))
-			assert.NoError(t, err)
-			err = w.Create("front", project.getPath(t))
-			assert.NoError(t, err)
-			s.setup(t, w)
-			s.test(t, w.Fix())
+			assert.NoError(t, w.Create("test", project.getPath(t), ""))
+			assert.NoError(t, os.WriteFile(config.getPath(t)+"/workspaces/test/functions/functions.bash", []byte("deploy() {\n}\n"), 0o777))
+			exec := NewMockCommander(t)
+			w.exec = exec
+			s.setup(t, exec)
+			stderr := &bytes.Buffer{}
+			err = w.RunFunction("test", "", []string{"deploy"}, append(s.options, WithOutput(os.Stdout, stderr))...)
+			s.test(t, stderr.String(), err)
 		})
 	}
 }
 
-func TestSetConfig(t *testing.T) {
+func TestRunFunctionWithDependencies(t *testing.T) {
 	config := &config{}
 	project := &project{}
+	failure := exec.Command("/bin/sh", "-c", "exit 3").Run()
+	script := func(function string) string {
+		return fmt.Sprintf("export WO_NAME=test && export WO_ENV=default && source %s/workspaces/test/envs/default.bash && source %s/workspaces/test/functions/functions.bash && %s", config.getPath(t), config.getPath(t), function)
+	}
 	type scenario struct {
-		name      string
-		workspace string
-		key       string
-		value     string
-		test      func(*testing.T, error)
+		name    string
+		options []func(*RunOptions)
+		setup   func(*testing.T, *MockCommander)
+		test    func(*testing.T, string, error)
 	}
 	scenarios := []scenario{
 		{
-			"Set a value in a workspace config",
-			"test",
-			"path",
-			"/tmp",
-			func(t *testing.T, err error) {
-				assert.NoError(t, err)
-				b, err := os.ReadFile(fmt.Sprintf("%s/%s", config.getPath(t), "workspaces/test/config.toml"))
+			"The dependencies are run first in order",
+			[]func(*RunOptions){},
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, mock.Anything, "-c", script("lint")).Return(nil).Once()
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, mock.Anything, "-c", script("build")).Return(nil).Once()
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, mock.Anything, "-c", script("deploy v1")).Return(nil).Once()
+			},
+			func(t *testing.T, stderr string, err error) {
 				assert.NoError(t, err)
-				assert.Equal(t, []byte("app = 'bash'\npath = '/tmp'\n"), b)
+				assert.Equal(t, "running the dependency `lint`\nrunning the dependency `build`\n", stderr)
 			},
 		},
 		{
-			"Set an unsupported value",
-			"test",
-			"whetevr",
-			"/home/user/project",
-			func(t *testing.T, err error) {
-				assert.Error(t, err)
+			"The function is not run when a dependency fails",
+			[]func(*RunOptions){},
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, mock.Anything, "-c", script("lint")).Return(failure).Once()
 			},
-		},
-		{
-			"Set an unexisting path",
-			"test",
-			"path",
-			"/home/user/project",
-			func(t *testing.T, err error) {
-				assert.Error(t, err)
-				assert.EqualError(t, err, `path "/home/user/project" does not exist`)
+			func(t *testing.T, stderr string, err error) {
+				assert.ErrorIs(t, err, ErrDependency)
+				assert.EqualError(t, err, "the function is not run because of the failure of its dependency `lint`: exit status 3")
+				assert.Equal(t, 3, ExitCode(err))
 			},
 		},
 		{
-			"Set an unsupported app",
-			"test",
-			"app",
-			"test",
-			func(t *testing.T, err error) {
-				assert.Error(t, err)
-				assert.EqualError(t, err, `app "test" is not supported`)
+			"The dependencies are skipped",
+			[]func(*RunOptions){WithoutDependencies()},
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, mock.Anything, "-c", script("deploy v1")).Return(nil).Once()
 			},
-		},
-		{
-			"Set a value in an unexisting workspace",
-			"whatever",
-			"path",
-			"/home/user/project",
-			func(t *testing.T, err error) {
-				assert.Error(t, err)
+			func(t *testing.T, stderr string, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, stderr)
 			},
 		},
 	}
@@ -704,31 +855,62 @@ func TestSetConfig(t *testing.T) {
 			os.RemoveAll(config.getPath(t))
 			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
 			assert.NoError(t, err)
-			err = w.Create("test", project.getPath(t))
-			assert.NoError(t, err)
-			s.test(t, w.SetConfig(s.workspace, map[string]string{s.key: s.value}))
+			assert.NoError(t, w.Create("test", project.getPath(t), ""))
+			assert.NoError(t, os.WriteFile(config.getPath(t)+"/workspaces/test/functions/functions.bash", []byte("# @needs build lint\ndeploy() {\n}\n\n# @needs lint\nbuild() {\n}\n\nlint() {\n}\n"), 0o777))
+			exec := NewMockCommander(t)
+			w.exec = exec
+			s.setup(t, exec)
+			stderr := &bytes.Buffer{}
+			err = w.RunFunction("test", "", []string{"deploy", "v1"}, append(s.options, WithOutput(os.Stdout, stderr))...)
+			s.test(t, stderr.String(), err)
 		})
 	}
 }
 
-func TestBuildAliases(t *testing.T) {
+func TestRunDependencies(t *testing.T) {
 	config := &config{}
 	project := &project{}
+	failure := exec.Command("/bin/sh", "-c", "exit 3").Run()
+	script := func(function string) string {
+		return fmt.Sprintf("export WO_NAME=test && export WO_ENV=default && source %s/workspaces/test/envs/default.bash && source %s/workspaces/test/functions/functions.bash && %s", config.getPath(t), config.getPath(t), function)
+	}
 	type scenario struct {
-		name   string
-		prefix string
-		test   func(*testing.T, []string, error)
+		name    string
+		options []func(*RunOptions)
+		setup   func(*testing.T, *MockCommander)
+		test    func(*testing.T, string, error)
 	}
 	scenarios := []scenario{
 		{
-			"Build aliases for all workspace",
-			"c_",
-			func(t *testing.T, aliases []string, e error) {
-				assert.NoError(t, e)
-				assert.Equal(t, []string{
-					fmt.Sprintf(`alias c_front="cd %s/front"`, project.getPath(t)),
-					fmt.Sprintf(`alias c_test="cd %s/test"`, project.getPath(t)),
-				}, aliases)
+			"The dependencies shared by the functions are run once",
+			[]func(*RunOptions){},
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, mock.Anything, "-c", script("lint")).Return(nil).Once()
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, mock.Anything, "-c", script("build")).Return(nil).Once()
+			},
+			func(t *testing.T, stderr string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "running the dependency `build`\nrunning the dependency `lint`\n", stderr)
+			},
+		},
+		{
+			"A dependency fails",
+			[]func(*RunOptions){},
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, mock.Anything, "-c", script("build")).Return(failure).Once()
+			},
+			func(t *testing.T, stderr string, err error) {
+				assert.ErrorIs(t, err, ErrDependency)
+				assert.Equal(t, 3, ExitCode(err))
+			},
+		},
+		{
+			"The dependencies are skipped",
+			[]func(*RunOptions){WithoutDependencies()},
+			func(t *testing.T, exec *MockCommander) {},
+			func(t *testing.T, stderr string, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, stderr)
 			},
 		},
 	}
@@ -737,23 +919,764 @@ func TestBuildAliases(t *testing.T) {
 			os.RemoveAll(config.getPath(t))
 			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
 			assert.NoError(t, err)
-			testProjectPath := fmt.Sprintf("%s/test", project.getPath(t))
-			assert.NoError(t, os.MkdirAll(testProjectPath, 0o777))
-			err = w.Create("test", testProjectPath)
-			assert.NoError(t, err)
-			frontProjectPath := fmt.Sprintf("%s/front", project.getPath(t))
-			assert.NoError(t, os.MkdirAll(frontProjectPath, 0o777))
-			err = w.Create("front", frontProjectPath)
-			assert.NoError(t, err)
-			aliases, err := w.BuildAliases(s.prefix)
-			s.test(t, aliases, err)
+			assert.NoError(t, w.Create("test", project.getPath(t), ""))
+			assert.NoError(t, os.WriteFile(config.getPath(t)+"/workspaces/test/functions/functions.bash", []byte("# @needs build lint\ndeploy() {\n}\n\n# @needs lint\ntest() {\n}\n\nbuild() {\n}\n\nlint() {\n}\n"), 0o777))
+			exec := NewMockCommander(t)
+			w.exec = exec
+			s.setup(t, exec)
+			stderr := &bytes.Buffer{}
+			err = w.RunDependencies("test", "", []string{"deploy", "test"}, append(s.options, WithOutput(os.Stdout, stderr))...)
+			s.test(t, stderr.String(), err)
 		})
 	}
 }
 
-func TestGetWorkspace(t *testing.T) {
-	type scenario struct {
-		name  string
+func TestRunFunctionCanceled(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Create("test", project.getPath(t), ""))
+	assert.NoError(t, os.WriteFile(config.getPath(t)+"/workspaces/test/functions/functions.bash", []byte("lint() {\n}\n"), 0o777))
+	exec := NewMockCommander(t)
+	w.exec = exec
+	ctx, cancel := context.WithCancel(context.Background())
+	exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, os.Stderr, mock.Anything, mock.Anything).Return(func(context.Context, string, string, io.Writer, io.Writer, ...string) error {
+		cancel()
+		return errors.New("signal: terminated")
+	})
+	err = w.RunFunction("test", "", []string{"lint"}, WithContext(ctx))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRunFunctionWithOutput(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Create("test", project.getPath(t), ""))
+	functionPath := config.getPath(t) + "/workspaces/test/functions/functions.bash"
+	assert.NoError(t, os.WriteFile(functionPath, []byte(`
+run-db() {
+
+}
+`), 0o777))
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	exec := NewMockCommander(t)
+	w.exec = exec
+	exec.On("command", mock.Anything, "bash", project.getPath(t), stdout, stderr, "-c", fmt.Sprintf("export WO_NAME=test && export WO_ENV=default && source %s/workspaces/test/envs/default.bash && source %s/workspaces/test/functions/functions.bash && run-db", config.getPath(t), config.getPath(t))).Return(nil)
+	assert.NoError(t, w.RunFunction("test", "", []string{"run-db"}, WithOutput(stdout, stderr)))
+}
+
+func TestRunFunctionWithVars(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	type scenario struct {
+		name  string
+		shell string
+		setup func(*testing.T, *MockCommander)
+	}
+	scenarios := []scenario{
+		{
+			"Run a function with variables and a bash shell",
+			"/bin/bash",
+			func(t *testing.T, exec *MockCommander) {
+				assert.NoError(t, os.WriteFile(config.getPath(t)+"/workspaces/test/functions/functions.bash", []byte(`
+run-db() {
+
+}
+`), 0o777))
+				exec.On("command", mock.Anything, "bash", project.getPath(t), os.Stdout, os.Stderr, "-c", fmt.Sprintf("export WO_NAME=test && export WO_ENV=default && export WO_VAR_GREETING='it'\\''s me' && export WO_VAR_PORT=8080 && source %s/workspaces/test/envs/default.bash && source %s/workspaces/test/functions/functions.bash && run-db", config.getPath(t), config.getPath(t))).Return(nil)
+			},
+		},
+		{
+			"Run a function with variables and a fish shell",
+			"/bin/fish",
+			func(t *testing.T, exec *MockCommander) {
+				assert.NoError(t, os.WriteFile(config.getPath(t)+"/workspaces/test/functions/functions.fish", []byte(`
+function run-db
+end
+`), 0o777))
+				exec.On("command", mock.Anything, "fish", project.getPath(t), os.Stdout, os.Stderr, "-C", "set -x -g WO_NAME test", "-C", "set -x -g WO_ENV default", "-C", `set -x -g WO_VAR_GREETING 'it\'s me'`, "-C", "set -x -g WO_VAR_PORT 8080", "-C", fmt.Sprintf("source %s/workspaces/test/envs/default.fish", config.getPath(t)), "-C", fmt.Sprintf("source %s/workspaces/test/functions/functions.fish", config.getPath(t)), "-c", "run-db").Return(nil)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.RemoveAll(config.getPath(t))
+			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath(s.shell), WithConfigPath(config.getPath(t)))
+			assert.NoError(t, err)
+			assert.NoError(t, w.Create("test", project.getPath(t), ""))
+			assert.NoError(t, w.SetConfig("test", map[string]any{"vars.port": "8080", "vars.greeting": "it's me"}))
+			exec := NewMockCommander(t)
+			w.exec = exec
+			s.setup(t, exec)
+			assert.NoError(t, w.RunFunction("test", "", []string{"run-db"}))
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	type scenario struct {
+		name      string
+		workspace string
+		test      func(*testing.T, error)
+	}
+	scenarios := []scenario{
+		{
+			"Remove an unexisting workspace",
+			"whatever",
+			func(t *testing.T, e error) {
+				assert.Error(t, e)
+			},
+		},
+		{
+			"Remove a workspace",
+			"test",
+			func(t *testing.T, e error) {
+				assert.NoError(t, e)
+				path := config.getPath(t)
+				_, err := os.Stat(path + "/workspaces/test/envs/prod.bash")
+				assert.True(t, os.IsNotExist(err))
+				_, err = os.Stat(path + "/workspaces/test/envs/dev.bash")
+				assert.True(t, os.IsNotExist(err))
+				_, err = os.Stat(path + "/workspaces/test/functions/functions.bash")
+				assert.True(t, os.IsNotExist(err))
+				_, err = os.Stat(path + "/workspaces/test/config.toml")
+				assert.True(t, os.IsNotExist(err))
+
+				_, err = os.Stat(path + "/workspaces/front/functions/functions.bash")
+				assert.NoError(t, err)
+				_, err = os.Stat(path + "/workspaces/front/envs/dev.bash")
+				assert.NoError(t, err)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.RemoveAll(config.getPath(t))
+			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+			assert.NoError(t, err)
+			err = w.Create("test", project.getPath(t), "")
+			assert.NoError(t, err)
+			err = w.CreateEnv("test", "prod")
+			assert.NoError(t, err)
+			err = w.CreateEnv("test", "dev")
+			assert.NoError(t, err)
+			err = w.Create("front", project.getPath(t), "")
+			assert.NoError(t, err)
+			err = w.CreateEnv("front", "dev")
+			assert.NoError(t, err)
+			s.test(t, w.Remove(s.workspace))
+		})
+	}
+}
+
+func TestFix(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	type scenario struct {
+		name  string
+		setup func(*testing.T, WorkspaceManager)
+		test  func(*testing.T, error)
+	}
+	scenarios := []scenario{
+		{
+			"Fix a failing workspace",
+			func(*testing.T, WorkspaceManager) {
+				path := config.getPath(t)
+				err := os.RemoveAll(path + "/workspaces/test/envs")
+				assert.NoError(t, err)
+				err = os.Remove(path + "/workspaces/test/functions/functions.bash")
+				assert.NoError(t, err)
+				err = os.RemoveAll(path + "/workspaces/front/envs")
+				assert.NoError(t, err)
+				err = os.Remove(path + "/workspaces/front/functions/functions.bash")
+				assert.NoError(t, err)
+			},
+			func(t *testing.T, e error) {
+				assert.NoError(t, e)
+				path := config.getPath(t)
+				_, err := os.Stat(path + "/workspaces/test/functions/functions.bash")
+				assert.NoError(t, err)
+				_, err = os.Stat(path + "/workspaces/test/envs/default.bash")
+				assert.NoError(t, err)
+				_, err = os.Stat(path + "/workspaces/front/functions/functions.bash")
+				assert.NoError(t, err)
+				_, err = os.Stat(path + "/workspaces/front/envs/default.bash")
+				assert.NoError(t, err)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.RemoveAll(config.getPath(t))
+			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+			assert.NoError(t, err)
+			err = w.Create("test", project.getPath(t), "")
+			assert.NoError(t, err)
+			err = w.Create("front", project.getPath(t), "")
+			assert.NoError(t, err)
+			s.setup(t, w)
+			s.test(t, w.Fix())
+		})
+	}
+}
+
+func TestSetConfig(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	type scenario struct {
+		name      string
+		workspace string
+		key       string
+		value     any
+		test      func(*testing.T, error)
+	}
+	scenarios := []scenario{
+		{
+			"Set a value in a workspace config",
+			"test",
+			"path",
+			"/tmp",
+			func(t *testing.T, err error) {
+				assert.NoError(t, err)
+				b, err := os.ReadFile(fmt.Sprintf("%s/%s", config.getPath(t), "workspaces/test/config.toml"))
+				assert.NoError(t, err)
+				assert.Equal(t, []byte("app = 'bash'\npath = '/tmp'\n"), b)
+			},
+		},
+		{
+			"Set tags in a workspace config",
+			"test",
+			"tags",
+			[]string{"backend", "go"},
+			func(t *testing.T, err error) {
+				assert.NoError(t, err)
+				b, err := os.ReadFile(fmt.Sprintf("%s/%s", config.getPath(t), "workspaces/test/config.toml"))
+				assert.NoError(t, err)
+				assert.Equal(t, fmt.Sprintf("app = 'bash'\npath = '%s'\ntags = ['backend', 'go']\n", project.getPath(t)), string(b))
+			},
+		},
+		{
+			"Set tags from a comma separated string",
+			"test",
+			"tags",
+			"backend, go,",
+			func(t *testing.T, err error) {
+				assert.NoError(t, err)
+				b, err := os.ReadFile(fmt.Sprintf("%s/%s", config.getPath(t), "workspaces/test/config.toml"))
+				assert.NoError(t, err)
+				assert.Equal(t, fmt.Sprintf("app = 'bash'\npath = '%s'\ntags = ['backend', 'go']\n", project.getPath(t)), string(b))
+			},
+		},
+		{
+			"Set tags with a wrong type",
+			"test",
+			"tags",
+			1,
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, `the value of "tags" must be a list`)
+			},
+		},
+		{
+			"Set the task runners exposed as functions",
+			"test",
+			"tasks",
+			"makefile,npm",
+			func(t *testing.T, err error) {
+				assert.NoError(t, err)
+				b, err := os.ReadFile(fmt.Sprintf("%s/%s", config.getPath(t), "workspaces/test/config.toml"))
+				assert.NoError(t, err)
+				assert.Equal(t, fmt.Sprintf("app = 'bash'\npath = '%s'\ntasks = ['makefile', 'npm']\n", project.getPath(t)), string(b))
+			},
+		},
+		{
+			"Set an unsupported task runner",
+			"test",
+			"tasks",
+			[]string{"makefile", "gradle"},
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, `"gradle" is not a supported source, it must be one of: makefile, npm, just, taskfile`)
+			},
+		},
+		{
+			"Set a path with a wrong type",
+			"test",
+			"path",
+			[]string{"/tmp"},
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, `the value of "path" must be a string`)
+			},
+		},
+		{
+			"Set a variable",
+			"test",
+			"vars.port",
+			"8080",
+			func(t *testing.T, err error) {
+				assert.NoError(t, err)
+				b, err := os.ReadFile(fmt.Sprintf("%s/%s", config.getPath(t), "workspaces/test/config.toml"))
+				assert.NoError(t, err)
+				assert.Equal(t, fmt.Sprintf("app = 'bash'\npath = '%s'\n\n[vars]\nport = '8080'\n", project.getPath(t)), string(b))
+			},
+		},
+		{
+			"Set a variable with an invalid name",
+			"test",
+			"vars.1port",
+			"8080",
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, `"1port" is not a valid variable name, it must comprise lower case letters, numbers and underscore and not start with a number`)
+			},
+		},
+		{
+			"Set a variable with an upper cased name",
+			"test",
+			"vars.API_URL",
+			"http://localhost",
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, `"API_URL" is not a valid variable name, it must comprise lower case letters, numbers and underscore and not start with a number`)
+			},
+		},
+		{
+			"Set the vars namespace",
+			"test",
+			"vars",
+			"8080",
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, `a variable name must be provided, e.g. "vars.port"`)
+			},
+		},
+		{
+			"Set an unsupported value",
+			"test",
+			"whetevr",
+			"/home/user/project",
+			func(t *testing.T, err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			"Set an unexisting path",
+			"test",
+			"path",
+			"/home/user/project",
+			func(t *testing.T, err error) {
+				assert.Error(t, err)
+				assert.EqualError(t, err, `path "/home/user/project" does not exist`)
+			},
+		},
+		{
+			"Set an unsupported app",
+			"test",
+			"app",
+			"test",
+			func(t *testing.T, err error) {
+				assert.Error(t, err)
+				assert.EqualError(t, err, `app "test" is not supported`)
+			},
+		},
+		{
+			"Set a value in an unexisting workspace",
+			"whatever",
+			"path",
+			"/home/user/project",
+			func(t *testing.T, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.RemoveAll(config.getPath(t))
+			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+			assert.NoError(t, err)
+			err = w.Create("test", project.getPath(t), "")
+			assert.NoError(t, err)
+			s.test(t, w.SetConfig(s.workspace, map[string]any{s.key: s.value}))
+		})
+	}
+}
+
+func TestGetConfig(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	type scenario struct {
+		name      string
+		workspace string
+		key       string
+		test      func(*testing.T, string, error)
+	}
+	scenarios := []scenario{
+		{
+			"Get a config value",
+			"test",
+			"app",
+			func(t *testing.T, value string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "bash", value)
+			},
+		},
+		{
+			"Get a variable",
+			"test",
+			"vars.port",
+			func(t *testing.T, value string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "8080", value)
+			},
+		},
+		{
+			"Get tags",
+			"test",
+			"tags",
+			func(t *testing.T, value string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "backend,go", value)
+			},
+		},
+		{
+			"Get a namespace",
+			"test",
+			"vars",
+			func(t *testing.T, value string, err error) {
+				assert.EqualError(t, err, `the config key "vars" is a namespace, a key like "vars.port" must be provided`)
+			},
+		},
+		{
+			"Get an undefined key",
+			"test",
+			"vars.whatever",
+			func(t *testing.T, value string, err error) {
+				assert.EqualError(t, err, `the config key "vars.whatever" is not defined`)
+			},
+		},
+		{
+			"Get a value in an unexisting workspace",
+			"whatever",
+			"app",
+			func(t *testing.T, value string, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.RemoveAll(config.getPath(t))
+			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+			assert.NoError(t, err)
+			assert.NoError(t, w.Create("test", project.getPath(t), ""))
+			assert.NoError(t, w.SetConfig("test", map[string]any{"vars.port": "8080", "tags": []string{"backend", "go"}}))
+			value, err := w.GetConfig(s.workspace, s.key)
+			s.test(t, value, err)
+		})
+	}
+}
+
+func TestUnsetConfig(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	type scenario struct {
+		name      string
+		workspace string
+		key       string
+		test      func(*testing.T, error)
+	}
+	scenarios := []scenario{
+		{
+			"Unset a variable",
+			"test",
+			"vars.port",
+			func(t *testing.T, err error) {
+				assert.NoError(t, err)
+				b, err := os.ReadFile(fmt.Sprintf("%s/%s", config.getPath(t), "workspaces/test/config.toml"))
+				assert.NoError(t, err)
+				assert.Equal(t, fmt.Sprintf("app = 'bash'\npath = '%s'\ntags = ['backend']\n\n[vars]\nhost = 'localhost'\n", project.getPath(t)), string(b))
+			},
+		},
+		{
+			"Unset an undefined variable",
+			"test",
+			"vars.whatever",
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, `the config key "vars.whatever" is not defined`)
+			},
+		},
+		{
+			"Unset a variable using an upper cased key",
+			"test",
+			"vars.PORT",
+			func(t *testing.T, err error) {
+				assert.NoError(t, err)
+				b, err := os.ReadFile(fmt.Sprintf("%s/%s", config.getPath(t), "workspaces/test/config.toml"))
+				assert.NoError(t, err)
+				assert.Equal(t, fmt.Sprintf("app = 'bash'\npath = '%s'\ntags = ['backend']\n\n[vars]\nhost = 'localhost'\n", project.getPath(t)), string(b))
+			},
+		},
+		{
+			"Unset the tags",
+			"test",
+			"tags",
+			func(t *testing.T, err error) {
+				assert.NoError(t, err)
+				b, err := os.ReadFile(fmt.Sprintf("%s/%s", config.getPath(t), "workspaces/test/config.toml"))
+				assert.NoError(t, err)
+				assert.Equal(t, fmt.Sprintf("app = 'bash'\npath = '%s'\n\n[vars]\nhost = 'localhost'\nport = '8080'\n", project.getPath(t)), string(b))
+			},
+		},
+		{
+			"Unset the path",
+			"test",
+			"path",
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, `the config key "path" is required and can't be unset`)
+			},
+		},
+		{
+			"Unset the path using an upper cased key",
+			"test",
+			"PATH",
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, `the config key "path" is required and can't be unset`)
+			},
+		},
+		{
+			"Unset the app using a capitalized key",
+			"test",
+			"App",
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, `the config key "app" is required and can't be unset`)
+			},
+		},
+		{
+			"Unset the app",
+			"test",
+			"app",
+			func(t *testing.T, err error) {
+				assert.EqualError(t, err, `the config key "app" is required and can't be unset`)
+			},
+		},
+		{
+			"Unset a variable in an unexisting workspace",
+			"whatever",
+			"vars.port",
+			func(t *testing.T, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.RemoveAll(config.getPath(t))
+			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+			assert.NoError(t, err)
+			assert.NoError(t, w.Create("test", project.getPath(t), ""))
+			assert.NoError(t, w.SetConfig("test", map[string]any{"vars.port": "8080", "vars.host": "localhost", "tags": []string{"backend"}}))
+			s.test(t, w.UnsetConfig(s.workspace, s.key))
+		})
+	}
+}
+
+func TestUnsetLastVariable(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+	assert.NoError(t, err)
+	assert.NoError(t, w.Create("test", project.getPath(t), ""))
+	assert.NoError(t, w.SetConfig("test", map[string]any{"vars.port": "8080"}))
+	assert.NoError(t, w.UnsetConfig("test", "vars.port"))
+	b, err := os.ReadFile(fmt.Sprintf("%s/%s", config.getPath(t), "workspaces/test/config.toml"))
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("app = 'bash'\npath = '%s'\n", project.getPath(t)), string(b))
+}
+
+func TestAddTags(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	type scenario struct {
+		name      string
+		workspace string
+		tags      []string
+		test      func(*testing.T, WorkspaceManager, error)
+	}
+	scenarios := []scenario{
+		{
+			"Add tags to a workspace",
+			"test",
+			[]string{"go", "backend", "go"},
+			func(t *testing.T, w WorkspaceManager, err error) {
+				assert.NoError(t, err)
+				wo, err := w.Get("test")
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"backend", "go"}, wo.Tags)
+			},
+		},
+		{
+			"Add tags to an unexisting workspace",
+			"whatever",
+			[]string{"go"},
+			func(t *testing.T, w WorkspaceManager, err error) {
+				assert.EqualError(t, err, "the workspace does not exist")
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.RemoveAll(config.getPath(t))
+			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+			assert.NoError(t, err)
+			assert.NoError(t, w.Create("test", project.getPath(t), ""))
+			s.test(t, w, w.AddTags(s.workspace, s.tags))
+		})
+	}
+}
+
+func TestRemoveTags(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	type scenario struct {
+		name      string
+		workspace string
+		tags      []string
+		test      func(*testing.T, WorkspaceManager, error)
+	}
+	scenarios := []scenario{
+		{
+			"Remove tags from a workspace",
+			"test",
+			[]string{"go", "backend"},
+			func(t *testing.T, w WorkspaceManager, err error) {
+				assert.NoError(t, err)
+				wo, err := w.Get("test")
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"cli"}, wo.Tags)
+			},
+		},
+		{
+			"Remove all tags from a workspace",
+			"test",
+			[]string{"go", "backend", "cli"},
+			func(t *testing.T, w WorkspaceManager, err error) {
+				assert.NoError(t, err)
+				wo, err := w.Get("test")
+				assert.NoError(t, err)
+				assert.Nil(t, wo.Tags)
+			},
+		},
+		{
+			"Remove an unexisting tag",
+			"test",
+			[]string{"whatever"},
+			func(t *testing.T, w WorkspaceManager, err error) {
+				assert.EqualError(t, err, `the tag "whatever" does not exist`)
+			},
+		},
+		{
+			"Remove tags from an unexisting workspace",
+			"whatever",
+			[]string{"go"},
+			func(t *testing.T, w WorkspaceManager, err error) {
+				assert.EqualError(t, err, "the workspace does not exist")
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.RemoveAll(config.getPath(t))
+			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+			assert.NoError(t, err)
+			assert.NoError(t, w.Create("test", project.getPath(t), ""))
+			assert.NoError(t, w.AddTags("test", []string{"backend", "cli", "go"}))
+			s.test(t, w, w.RemoveTags(s.workspace, s.tags))
+		})
+	}
+}
+
+func TestBuildAliases(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	type scenario struct {
+		name   string
+		app    string
+		prefix string
+		test   func(*testing.T, []string, error)
+	}
+	scenarios := []scenario{
+		{
+			"Build aliases for all workspace",
+			"bash",
+			"c_",
+			func(t *testing.T, aliases []string, e error) {
+				assert.NoError(t, e)
+				assert.Equal(t, []string{
+					fmt.Sprintf(`alias c_front="cd %s/front"`, project.getPath(t)),
+					fmt.Sprintf(`alias c_test="cd %s/test"`, project.getPath(t)),
+				}, aliases)
+			},
+		},
+		{
+			"Build aliases for nushell",
+			"nu",
+			"c_",
+			func(t *testing.T, aliases []string, e error) {
+				assert.NoError(t, e)
+				assert.Equal(t, []string{
+					fmt.Sprintf(`alias c_front = cd "%s/front"`, project.getPath(t)),
+					fmt.Sprintf(`alias c_test = cd "%s/test"`, project.getPath(t)),
+				}, aliases)
+			},
+		},
+		{
+			"Build functions for POSIX shells",
+			"sh",
+			"c_",
+			func(t *testing.T, aliases []string, e error) {
+				assert.NoError(t, e)
+				assert.Equal(t, []string{
+					fmt.Sprintf(`c_front() { cd %s/front; }`, project.getPath(t)),
+					fmt.Sprintf(`c_test() { cd %s/test; }`, project.getPath(t)),
+				}, aliases)
+			},
+		},
+		{
+			"Fallback to aliases for POSIX shells when the name is not a valid function name",
+			"dash",
+			"c-",
+			func(t *testing.T, aliases []string, e error) {
+				assert.NoError(t, e)
+				assert.Equal(t, []string{
+					fmt.Sprintf(`alias c-front="cd %s/front"`, project.getPath(t)),
+					fmt.Sprintf(`alias c-test="cd %s/test"`, project.getPath(t)),
+				}, aliases)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.RemoveAll(config.getPath(t))
+			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+			assert.NoError(t, err)
+			testProjectPath := fmt.Sprintf("%s/test", project.getPath(t))
+			assert.NoError(t, os.MkdirAll(testProjectPath, 0o777))
+			err = w.Create("test", testProjectPath, "")
+			assert.NoError(t, err)
+			frontProjectPath := fmt.Sprintf("%s/front", project.getPath(t))
+			assert.NoError(t, os.MkdirAll(frontProjectPath, 0o777))
+			err = w.Create("front", frontProjectPath, "")
+			assert.NoError(t, err)
+			aliases, err := w.BuildAliases(s.app, s.prefix)
+			s.test(t, aliases, err)
+		})
+	}
+}
+
+func TestGetWorkspace(t *testing.T) {
+	type scenario struct {
+		name  string
 		setup func(*testing.T, string, string, WorkspaceManager)
 		test  func(*testing.T, string, string, Workspace, error)
 	}
@@ -812,6 +1735,16 @@ func TestGetWorkspace(t *testing.T) {
 				assert.Error(t, err)
 			},
 		},
+		{
+			"Get a workspace with tags",
+			func(t *testing.T, projectPath string, configPath string, w WorkspaceManager) {
+				assert.NoError(t, os.WriteFile(configPath+"/workspaces/api/config.toml", []byte(fmt.Sprintf("app = 'bash'\npath = '%s'\ntags = ['backend', 'go']", projectPath)), 0o666))
+			},
+			func(t *testing.T, projectPath string, configPath string, workspace Workspace, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"backend", "go"}, workspace.Tags)
+			},
+		},
 		{
 			"Get a workspace",
 			func(t *testing.T, projectPath string, configPath string, w WorkspaceManager) {
@@ -831,10 +1764,156 @@ func TestGetWorkspace(t *testing.T) {
 			assert.NoError(t, err)
 			projectPath := project.getPath(t)
 			configPath := config.getPath(t)
-			assert.NoError(t, w.Create("api", projectPath))
+			assert.NoError(t, w.Create("api", projectPath, ""))
 			s.setup(t, projectPath, configPath, w)
 			workspace, err := w.getWorkspace("api")
 			s.test(t, projectPath, configPath, workspace, err)
 		})
 	}
 }
+
+func TestMixedShells(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	fishManager, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/usr/bin/fish"), WithConfigPath(config.getPath(t)))
+	assert.NoError(t, err)
+	assert.NoError(t, fishManager.Create("api", project.getPath(t), ""))
+	assert.NoError(t, os.WriteFile(fmt.Sprintf("%s/workspaces/api/functions/functions.fish", config.getPath(t)), []byte("function run-db -d 'Run the db'\nend\n"), 0o666))
+
+	bashManager, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+	assert.NoError(t, err)
+	assert.NoError(t, bashManager.Create("front", project.getPath(t), ""))
+
+	workspaces, err := bashManager.List()
+	assert.NoError(t, err)
+	assert.Len(t, workspaces, 2)
+	assert.Equal(t, "fish", workspaces[0].Config["app"])
+	assert.Equal(t, []Function{{Name: "run-db", Description: "Run the db"}}, workspaces[0].Functions.Functions)
+	assert.Equal(t, "bash", workspaces[1].Config["app"])
+
+	exec := NewMockCommander(t)
+	exec.On("command", mock.Anything, "fish", project.getPath(t), os.Stdout, os.Stderr, "-C", "set -x -g WO_NAME api", "-C", "set -x -g WO_ENV default", "-C", fmt.Sprintf("source %s/workspaces/api/envs/default.fish", config.getPath(t)), "-C", fmt.Sprintf("source %s/workspaces/api/functions/functions.fish", config.getPath(t)), "-c", "run-db").Return(nil)
+	bashManager.exec = exec
+	assert.NoError(t, bashManager.RunFunction("api", "", []string{"run-db"}))
+
+	assert.NoError(t, bashManager.CreateEnv("api", "prod"))
+	assert.FileExists(t, fmt.Sprintf("%s/workspaces/api/envs/prod.fish", config.getPath(t)))
+
+	assert.NoError(t, bashManager.SetConfig("api", map[string]any{"app": "zsh"}))
+	assert.FileExists(t, fmt.Sprintf("%s/workspaces/api/functions/functions.zsh", config.getPath(t)))
+	assert.FileExists(t, fmt.Sprintf("%s/workspaces/api/envs/default.zsh", config.getPath(t)))
+	assert.FileExists(t, fmt.Sprintf("%s/workspaces/api/envs/prod.zsh", config.getPath(t)))
+	assert.NoFileExists(t, fmt.Sprintf("%s/workspaces/api/functions/functions.fish", config.getPath(t)))
+	w, err := bashManager.Get("api")
+	assert.NoError(t, err)
+	assert.Equal(t, "zsh", w.Config["app"])
+}
+
+func TestCreateEnvVariableStatement(t *testing.T) {
+	w := WorkspaceManager{}
+	for app, expected := range map[string]string{
+		"bash": `export WO_VAR_GREETING='it'\''s "me"'`,
+		"dash": `export WO_VAR_GREETING='it'\''s "me"'`,
+		"ksh":  `export WO_VAR_GREETING='it'\''s "me"'`,
+		"sh":   `export WO_VAR_GREETING='it'\''s "me"'`,
+		"zsh":  `export WO_VAR_GREETING='it'\''s "me"'`,
+		"fish": `set -x -g WO_VAR_GREETING 'it\'s "me"'`,
+		"nu":   `$env.WO_VAR_GREETING = "it's \"me\""`,
+	} {
+		assert.Equal(t, expected, w.CreateEnvVariableStatement(app, "WO_VAR_GREETING", `it's "me"`), app)
+	}
+	assert.Equal(t, `$env.WO_VAR_PORT = "8080"`, w.CreateEnvVariableStatement("nu", "WO_VAR_PORT", "8080"))
+}
+
+func TestRunFunctionWithAdditionalShells(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	type scenario struct {
+		name  string
+		shell string
+		setup func(*testing.T, *MockCommander)
+	}
+	scenarios := []scenario{
+		{
+			"Run a function with a ksh shell",
+			"/bin/ksh",
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "ksh", project.getPath(t), os.Stdout, os.Stderr, "-c", fmt.Sprintf("export WO_NAME=test && export WO_ENV=default && . %s/workspaces/test/envs/default.ksh && . %s/workspaces/test/functions/functions.ksh && run-db", config.getPath(t), config.getPath(t))).Return(nil)
+			},
+		},
+		{
+			"Run a function with a dash shell",
+			"/usr/bin/dash",
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "dash", project.getPath(t), os.Stdout, os.Stderr, "-c", fmt.Sprintf("export WO_NAME=test && export WO_ENV=default && . %s/workspaces/test/envs/default.dash && . %s/workspaces/test/functions/functions.dash && run-db", config.getPath(t), config.getPath(t))).Return(nil)
+			},
+		},
+		{
+			"Run a function with a nushell shell",
+			"/usr/bin/nu",
+			func(t *testing.T, exec *MockCommander) {
+				exec.On("command", mock.Anything, "nu", project.getPath(t), os.Stdout, os.Stderr, "-c", fmt.Sprintf(`$env.WO_NAME = "test"; $env.WO_ENV = "default"; source %s/workspaces/test/envs/default.nu; source %s/workspaces/test/functions/functions.nu; run-db`, config.getPath(t), config.getPath(t))).Return(nil)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			os.RemoveAll(config.getPath(t))
+			w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath(s.shell), WithConfigPath(config.getPath(t)))
+			assert.NoError(t, err)
+			assert.NoError(t, w.Create("test", project.getPath(t), ""))
+			content := "run-db() {\n  echo run\n}\n"
+			if w.shell == "nu" {
+				content = "def run-db [] {\n  print run\n}\n"
+			}
+			assert.NoError(t, os.WriteFile(fmt.Sprintf("%s/workspaces/test/functions/functions.%s", config.getPath(t), w.shell), []byte(content), 0o666))
+			exec := NewMockCommander(t)
+			s.setup(t, exec)
+			w.exec = exec
+			assert.NoError(t, w.RunFunction("test", "", []string{"run-db"}))
+		})
+	}
+}
+
+func TestGetWorkspacesModTime(t *testing.T) {
+	config := &config{}
+	project := &project{}
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(config.getPath(t)))
+	assert.NoError(t, err)
+
+	modTime, err := w.GetWorkspacesModTime()
+	assert.NoError(t, err)
+	assert.True(t, modTime.IsZero())
+
+	assert.NoError(t, w.Create("api", project.getPath(t), ""))
+	assert.NoError(t, os.Chtimes(fmt.Sprintf("%s/workspaces", config.getPath(t)), time.Unix(10, 0), time.Unix(10, 0)))
+	assert.NoError(t, os.Chtimes(fmt.Sprintf("%s/workspaces/api/config.toml", config.getPath(t)), time.Unix(10, 0), time.Unix(10, 0)))
+	modTime, err = w.GetWorkspacesModTime()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(10, 0), modTime)
+
+	assert.NoError(t, w.Create("front", project.getPath(t), ""))
+	modTime, err = w.GetWorkspacesModTime()
+	assert.NoError(t, err)
+	assert.True(t, modTime.After(time.Unix(10, 0)))
+
+	// A config edited by hand is taken into account
+	assert.NoError(t, os.Chtimes(fmt.Sprintf("%s/workspaces", config.getPath(t)), time.Unix(10, 0), time.Unix(10, 0)))
+	assert.NoError(t, os.Chtimes(fmt.Sprintf("%s/workspaces/api/config.toml", config.getPath(t)), time.Unix(10, 0), time.Unix(10, 0)))
+	assert.NoError(t, os.Chtimes(fmt.Sprintf("%s/workspaces/front/config.toml", config.getPath(t)), time.Unix(20, 0), time.Unix(20, 0)))
+	modTime, err = w.GetWorkspacesModTime()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Unix(20, 0), modTime)
+
+	// The workspaces folder is updated when a config changes so the shells
+	// only have to check it
+	assert.NoError(t, w.SetConfig("api", map[string]any{"tags": "backend"}))
+	info, err := os.Stat(fmt.Sprintf("%s/workspaces", config.getPath(t)))
+	assert.NoError(t, err)
+	assert.True(t, info.ModTime().After(time.Unix(20, 0)))
+	assert.NoError(t, os.Chtimes(fmt.Sprintf("%s/workspaces", config.getPath(t)), time.Unix(10, 0), time.Unix(10, 0)))
+	assert.NoError(t, w.UnsetConfig("api", "tags"))
+	info, err = os.Stat(fmt.Sprintf("%s/workspaces", config.getPath(t)))
+	assert.NoError(t, err)
+	assert.True(t, info.ModTime().After(time.Unix(20, 0)))
+}
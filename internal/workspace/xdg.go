@@ -0,0 +1,69 @@
+package workspace
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// resolveXDGDir returns the wo directory inside the base directory defined by
+// the XDG variable, the fallback relative to the home directory is used when the
+// variable is not defined or is not an absolute path as stated by the specification
+func resolveXDGDir(variable string, home string, fallback string) string {
+	dir := os.Getenv(variable)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(home, fallback)
+	}
+	return filepath.Join(dir, appDir)
+}
+
+// GetLegacyConfigDir returns the config directory of the previous versions
+// when it must be moved to the XDG config directory, it's empty otherwise
+func (s WorkspaceManager) GetLegacyConfigDir() string {
+	if !isConfigDirMigrationPending(s.legacyConfigDir, s.configDir) {
+		return ""
+	}
+	return s.legacyConfigDir
+}
+
+// MigrateConfigDir moves the config directory of the previous versions to the
+// XDG config directory, it returns the directory moved if any
+func (s WorkspaceManager) MigrateConfigDir() (string, error) {
+	from := s.GetLegacyConfigDir()
+	if from == "" {
+		return "", nil
+	}
+	return from, migrateConfigDir(from, s.configDir)
+}
+
+// isConfigDirMigrationPending tells if the legacy config directory exists
+// while the new location is not populated yet
+func isConfigDirMigrationPending(from string, to string) bool {
+	if from == "" || filepath.Clean(from) == filepath.Clean(to) {
+		return false
+	}
+	if _, err := os.Stat(from); err != nil {
+		return false
+	}
+	_, err := os.Stat(to)
+	return os.IsNotExist(err)
+}
+
+// migrateConfigDir moves the legacy config directory to its new location when
+// the new location is not populated yet
+func migrateConfigDir(from string, to string) error {
+	if !isConfigDirMigrationPending(from, to) {
+		return nil
+	}
+	err := os.MkdirAll(filepath.Dir(to), 0o777)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(from, to)
+	if err != nil {
+		return fmt.Errorf(`the config directory "%s" can't be moved to "%s", move it manually: %w`, from, to, err)
+	}
+	slog.Debug("config directory migrated", slog.String("legacy", from), slog.String("new", to))
+	return nil
+}
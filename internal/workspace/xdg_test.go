@@ -0,0 +1,134 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveXDGDir(t *testing.T) {
+	type scenario struct {
+		name  string
+		value string
+		test  func(*testing.T, string)
+	}
+	scenarios := []scenario{
+		{
+			"The variable is not defined",
+			"",
+			func(t *testing.T, dir string) {
+				assert.Equal(t, "/home/user/.config/wo", dir)
+			},
+		},
+		{
+			"The variable is defined",
+			"/tmp/config",
+			func(t *testing.T, dir string) {
+				assert.Equal(t, "/tmp/config/wo", dir)
+			},
+		},
+		{
+			"The variable is not an absolute path",
+			"config",
+			func(t *testing.T, dir string) {
+				assert.Equal(t, "/home/user/.config/wo", dir)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			t.Setenv("XDG_CONFIG_HOME", s.value)
+			s.test(t, resolveXDGDir("XDG_CONFIG_HOME", "/home/user", ".config"))
+		})
+	}
+}
+
+func TestMigrateConfigDir(t *testing.T) {
+	type scenario struct {
+		name  string
+		setup func(*testing.T, string, string)
+		test  func(*testing.T, string, string, error)
+	}
+	scenarios := []scenario{
+		{
+			"Nothing to migrate",
+			func(t *testing.T, from string, to string) {},
+			func(t *testing.T, from string, to string, err error) {
+				assert.NoError(t, err)
+				assert.NoDirExists(t, to)
+			},
+		},
+		{
+			"Move the legacy directory",
+			func(t *testing.T, from string, to string) {
+				assert.NoError(t, os.MkdirAll(filepath.Join(from, "workspaces"), 0o777))
+				assert.NoError(t, os.WriteFile(filepath.Join(from, "config.toml"), []byte(`theme = "dark"`), 0o666))
+			},
+			func(t *testing.T, from string, to string, err error) {
+				assert.NoError(t, err)
+				assert.NoDirExists(t, from)
+				assert.DirExists(t, filepath.Join(to, "workspaces"))
+				content, err := os.ReadFile(filepath.Join(to, "config.toml"))
+				assert.NoError(t, err)
+				assert.Equal(t, `theme = "dark"`, string(content))
+			},
+		},
+		{
+			"Keep the new directory when both exist",
+			func(t *testing.T, from string, to string) {
+				assert.NoError(t, os.MkdirAll(from, 0o777))
+				assert.NoError(t, os.MkdirAll(to, 0o777))
+			},
+			func(t *testing.T, from string, to string, err error) {
+				assert.NoError(t, err)
+				assert.DirExists(t, from)
+				assert.DirExists(t, to)
+			},
+		},
+	}
+	for _, s := range scenarios {
+		t.Run(s.name, func(t *testing.T) {
+			dir := t.TempDir()
+			from := filepath.Join(dir, "home", ".config", "wo")
+			to := filepath.Join(dir, "xdg", "wo")
+			s.setup(t, from, to)
+			s.test(t, from, to, migrateConfigDir(from, to))
+		})
+	}
+}
+
+func TestNewWorkspaceManagerWithXDGDirs(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", filepath.Join(dir, "home"))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "home", ".config", "wo", "workspaces"), 0o777))
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(dir, "state"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(dir, "cache"))
+	w, err := NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"))
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "config", "wo"), w.GetConfigDir())
+	assert.Equal(t, filepath.Join(dir, "state", "wo"), w.GetStateDir())
+	assert.Equal(t, filepath.Join(dir, "cache", "wo"), w.GetCacheDir())
+
+	// The legacy directory is only moved on demand
+	assert.DirExists(t, filepath.Join(dir, "home", ".config", "wo"))
+	assert.NoDirExists(t, filepath.Join(dir, "config", "wo"))
+	assert.Equal(t, filepath.Join(dir, "home", ".config", "wo"), w.GetLegacyConfigDir())
+	from, err := w.MigrateConfigDir()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "home", ".config", "wo"), from)
+	assert.DirExists(t, filepath.Join(dir, "config", "wo", "workspaces"))
+	assert.NoDirExists(t, filepath.Join(dir, "home", ".config", "wo"))
+	assert.Empty(t, w.GetLegacyConfigDir())
+	from, err = w.MigrateConfigDir()
+	assert.NoError(t, err)
+	assert.Empty(t, from)
+
+	// Nothing is moved when the config directory is overridden
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "home", ".config", "wo"), 0o777))
+	w, err = NewWorkspaceManager(WithEditor("emacs", "emacs"), WithShellPath("/bin/bash"), WithConfigPath(filepath.Join(dir, "custom")))
+	assert.NoError(t, err)
+	assert.Empty(t, w.GetLegacyConfigDir())
+}